@@ -0,0 +1,73 @@
+package slicex
+
+// This file contains the variants of the set operations that append their results to
+// destination slices supplied by the caller, in the style of [slices.AppendSeq] and
+// [strconv.AppendInt], so that pre-sized or pooled buffers can be reused.
+// Only the maps used for detecting duplicates are allocated, plus the growth of the
+// destinations when they lack capacity. The elements already in the destinations are not
+// taken into account for removing duplicates.
+
+// AppendUnique appends to dst the elements of s with no duplicates, preserving their order,
+// and returns the extended slice.
+func AppendUnique[E comparable](dst, s []E) []E {
+	seen := make(map[E]struct{}, len(s))
+	return uniqueInto(dst, seen, s)
+}
+
+// AppendIntersection appends to dst the unique elements found in all the inputs,
+// in the order of the first input, and returns the extended slice.
+func AppendIntersection[E comparable](dst []E, inputs ...[]E) []E {
+	switch len(inputs) {
+	case 0:
+		return dst
+
+	case 1:
+		return AppendUnique(dst, inputs[0])
+
+	default:
+		i := smallest(inputs)
+		if len(inputs[i]) == 0 {
+			return dst
+		}
+
+		interSet := toSet(inputs[i])
+		current := make(map[E]struct{}, len(inputs[i]))
+		intersectSets(interSet, current, i, inputs)
+		return intersectionInto(dst, interSet, inputs[0])
+	}
+}
+
+// AppendUnion appends to dst the unique elements found in any of the inputs,
+// in the order of the inputs, and returns the extended slice.
+func AppendUnion[E comparable](dst []E, inputs ...[]E) []E {
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	seen := make(map[E]struct{}, size)
+	return unionInto(dst, seen, inputs)
+}
+
+// AppendDifference appends to dst the unique elements of s1 not in s2,
+// in the order of s1, and returns the extended slice.
+func AppendDifference[E comparable](dst, s1, s2 []E) []E {
+	return differenceInto(dst, toSet(s2), s1)
+}
+
+// AppendSymmetricDifference appends to dst the unique elements present in either s1 or s2, but not both,
+// in the order of append(s1, s2...), and returns the extended slice.
+func AppendSymmetricDifference[E comparable](dst, s1, s2 []E) []E {
+	u2 := toSet(s2)
+	seen := make(map[E]struct{}, len(s1)+len(u2))
+	return symmetricDifferenceInto(dst, u2, seen, s1, s2)
+}
+
+// AppendPartition appends to d12, inter and d21 respectively the unique elements only in s1,
+// in both, and only in s2, in the order of their first appearance in s1, then s2.
+// It returns the three extended slices.
+func AppendPartition[E comparable](d12, inter, d21, s1, s2 []E) ([]E, []E, []E) {
+	u2 := toSet(s2)
+	seen := make(map[E]struct{}, len(s1)+len(u2))
+	return partitionInto(d12, inter, d21, u2, seen, s1, s2)
+}
@@ -0,0 +1,61 @@
+package slicex
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestAppendSetOperations(t *testing.T) {
+	prefix := []int{-1, -2}
+
+	for range 100 {
+		s1 := RandomInts(rand.IntN(100), 50)
+		s2 := RandomInts(rand.IntN(100), 50)
+		s3 := RandomInts(rand.IntN(100), 50)
+
+		if result, expected := AppendUnique(slices.Clone(prefix), s1), append(slices.Clone(prefix), Unique(s1)...); !slices.Equal(result, expected) {
+			t.Fatalf("AppendUnique: expected %v, got %v", expected, result)
+		}
+
+		if result, expected := AppendIntersection(slices.Clone(prefix), s1, s2, s3), append(slices.Clone(prefix), Intersection(s1, s2, s3)...); !slices.Equal(result, expected) {
+			t.Fatalf("AppendIntersection: expected %v, got %v", expected, result)
+		}
+
+		if result, expected := AppendUnion(slices.Clone(prefix), s1, s2, s3), append(slices.Clone(prefix), Union(s1, s2, s3)...); !slices.Equal(result, expected) {
+			t.Fatalf("AppendUnion: expected %v, got %v", expected, result)
+		}
+
+		if result, expected := AppendDifference(slices.Clone(prefix), s1, s2), append(slices.Clone(prefix), Difference(s1, s2)...); !slices.Equal(result, expected) {
+			t.Fatalf("AppendDifference: expected %v, got %v", expected, result)
+		}
+
+		if result, expected := AppendSymmetricDifference(slices.Clone(prefix), s1, s2), append(slices.Clone(prefix), SymmetricDifference(s1, s2)...); !slices.Equal(result, expected) {
+			t.Fatalf("AppendSymmetricDifference: expected %v, got %v", expected, result)
+		}
+
+		d12, inter, d21 := AppendPartition(slices.Clone(prefix), slices.Clone(prefix), slices.Clone(prefix), s1, s2)
+		e12, eInter, e21 := Partition(s1, s2)
+		if !slices.Equal(d12, append(slices.Clone(prefix), e12...)) ||
+			!slices.Equal(inter, append(slices.Clone(prefix), eInter...)) ||
+			!slices.Equal(d21, append(slices.Clone(prefix), e21...)) {
+			t.Fatalf("AppendPartition: expected (%v,%v,%v), got (%v,%v,%v)", e12, eInter, e21, d12, inter, d21)
+		}
+	}
+}
+
+func TestAppendReusesBuffer(t *testing.T) {
+	s1 := []int{1, 2, 3, 2, 1}
+	s2 := []int{3, 4}
+
+	buf := make([]int, 0, 10)
+	union := AppendUnion(buf, s1, s2)
+
+	if !slices.Equal(union, []int{1, 2, 3, 4}) {
+		t.Fatalf("expected %v, got %v", []int{1, 2, 3, 4}, union)
+	}
+
+	if &union[0] != &buf[:1][0] {
+		t.Fatalf("expected the result to reuse the buffer")
+	}
+}
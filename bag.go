@@ -0,0 +1,199 @@
+package slicex
+
+// Bag is a multiset, which counts how many times each element appears.
+// Elements with a count of zero are not stored.
+//
+// A nil Bag can be read from but not added to. Use [BagFromSlice] or make to create one.
+type Bag[E comparable] map[E]int
+
+// BagFromSlice returns a new [Bag] with the counts of the elements of s.
+func BagFromSlice[E comparable](s []E) Bag[E] {
+	b := make(Bag[E], len(s))
+	for _, e := range s {
+		b[e]++
+	}
+	return b
+}
+
+// Count returns how many times the element appears in the bag.
+func (b Bag[E]) Count(e E) int { return b[e] }
+
+// Len returns the number of distinct elements in the bag.
+func (b Bag[E]) Len() int { return len(b) }
+
+// Total returns the number of elements in the bag, counted with multiplicity.
+func (b Bag[E]) Total() int {
+	var total int
+	for _, c := range b {
+		total += c
+	}
+	return total
+}
+
+// Add increments by one the count of each of the elements.
+func (b Bag[E]) Add(elems ...E) {
+	for _, e := range elems {
+		b[e]++
+	}
+}
+
+// Subtract decrements by one the count of each of the elements, if present.
+// Counts never go below zero.
+func (b Bag[E]) Subtract(elems ...E) {
+	for _, e := range elems {
+		switch b[e] {
+		case 0:
+			continue
+		case 1:
+			delete(b, e)
+		default:
+			b[e]--
+		}
+	}
+}
+
+// MostCommon returns the k most common elements and their counts, sorted by count in descending order.
+// The order of elements with the same count is unspecified.
+func (b Bag[E]) MostCommon(k int) Pairs[E, int] {
+	return ToPairs(b).MaxK(k)
+}
+
+// ToSlice returns a new slice containing each element as many times as its count.
+// Equal elements are next to each other, but the order is otherwise unspecified.
+func (b Bag[E]) ToSlice() []E {
+	s := make([]E, 0, b.Total())
+	for e, c := range b {
+		for range c {
+			s = append(s, e)
+		}
+	}
+	return s
+}
+
+// BagUnion returns the multiset union of the slices: a new slice where each element appears
+// as many times as in the slice that contains it the most.
+// The order of elements reflects the one in the original slices.
+func BagUnion[E comparable](inputs ...[]E) []E {
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	if size == 0 {
+		return []E{}
+	}
+
+	union := make([]E, 0, size)
+	emitted := make(Bag[E], size)
+	current := make(Bag[E], size)
+
+	for _, s := range inputs {
+		clear(current)
+		for _, e := range s {
+			current[e]++
+			if current[e] > emitted[e] {
+				// the current slice has more copies of e than any of the previous ones
+				union = append(union, e)
+				emitted[e]++
+			}
+		}
+	}
+	return union
+}
+
+// BagIntersection returns the multiset intersection of the slices: a new slice where each element
+// appears as many times as in the slice that contains it the least.
+// The order of elements is the same as the one of the first slice.
+func BagIntersection[E comparable](inputs ...[]E) []E {
+	switch len(inputs) {
+	case 0:
+		return nil
+
+	case 1:
+		inter := make([]E, len(inputs[0]))
+		copy(inter, inputs[0])
+		return inter
+
+	default:
+		counts := BagFromSlice(inputs[0])
+		for _, s := range inputs[1:] {
+			current := BagFromSlice(s)
+			for e, c := range counts {
+				counts[e] = min(c, current[e])
+				if counts[e] == 0 {
+					delete(counts, e)
+				}
+			}
+
+			if len(counts) == 0 {
+				return []E{}
+			}
+		}
+
+		inter := make([]E, 0, counts.Total())
+		for _, e := range inputs[0] {
+			if counts[e] > 0 {
+				inter = append(inter, e)
+				counts[e]--
+			}
+		}
+		return inter
+	}
+}
+
+// BagDifference returns the multiset difference of s1 and s2: a new slice where each element appears
+// as many times as in s1 minus the times it appears in s2, or zero times if the difference is negative.
+// The first occurrences in s1 are the ones removed, and the order of the others is preserved.
+func BagDifference[E comparable](s1, s2 []E) []E {
+	counts2 := BagFromSlice(s2)
+	diff := make([]E, 0, len(s1))
+
+	for _, e := range s1 {
+		if counts2[e] > 0 {
+			counts2[e]--
+			continue
+		}
+		diff = append(diff, e)
+	}
+	return diff
+}
+
+// BagSymmetricDifference returns the multiset symmetric difference of s1 and s2: a new slice where
+// each element appears as many times as the absolute difference of its counts in s1 and s2.
+// The order of elements is the same as the one of append(BagDifference(s1, s2), BagDifference(s2, s1)...).
+func BagSymmetricDifference[E comparable](s1, s2 []E) []E {
+	d12, _, d21 := BagPartition(s1, s2)
+	return append(d12, d21...)
+}
+
+// BagPartition returns the multiset partition of s1 and s2, which is the same as
+// BagDifference(s1, s2), BagIntersection(s1, s2) and BagDifference(s2, s1), computed together.
+func BagPartition[E comparable](s1, s2 []E) (d12, inter, d21 []E) {
+	counts2 := BagFromSlice(s2)
+	matched := make(Bag[E], min(len(s1), len(counts2)))
+
+	d12 = make([]E, 0, len(s1))
+	inter = make([]E, 0)
+	d21 = make([]E, 0, len(s2))
+
+	for _, e := range s1 {
+		if counts2[e] > 0 {
+			counts2[e]--
+			matched[e]++
+			inter = append(inter, e)
+			continue
+		}
+		d12 = append(d12, e)
+	}
+
+	for _, e := range s2 {
+		if matched[e] > 0 {
+			// this occurrence was matched by one in s1
+			matched[e]--
+			continue
+		}
+		d21 = append(d21, e)
+	}
+
+	return d12, inter, d21
+}
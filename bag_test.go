@@ -0,0 +1,100 @@
+package slicex
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestBag(t *testing.T) {
+	bag := BagFromSlice([]string{"a", "b", "a", "c", "a", "b"})
+	if bag.Count("a") != 3 || bag.Count("b") != 2 || bag.Count("c") != 1 || bag.Count("d") != 0 {
+		t.Fatalf("BagFromSlice: unexpected bag %v", bag)
+	}
+
+	if bag.Len() != 3 || bag.Total() != 6 {
+		t.Fatalf("expected len 3 and total 6, got %d and %d", bag.Len(), bag.Total())
+	}
+
+	bag.Add("d", "c")
+	bag.Subtract("a", "b", "b", "x")
+	if expected := (Bag[string]{"a": 2, "c": 2, "d": 1}); !reflect.DeepEqual(bag, expected) {
+		t.Fatalf("Add/Subtract: expected %v, got %v", expected, bag)
+	}
+
+	common := bag.MostCommon(1)
+	if len(common) != 1 || common[0].Val != 2 || (common[0].Key != "a" && common[0].Key != "c") {
+		t.Fatalf("MostCommon: unexpected %v", common)
+	}
+
+	elems := bag.ToSlice()
+	slices.Sort(elems)
+	if expected := []string{"a", "a", "c", "c", "d"}; !reflect.DeepEqual(elems, expected) {
+		t.Fatalf("ToSlice: expected %v, got %v", expected, elems)
+	}
+}
+
+func TestBagUnion(t *testing.T) {
+	tests := []struct {
+		slices   [][]int
+		expected []int
+	}{
+		{slices: nil, expected: []int{}},
+		{slices: [][]int{{1, 1, 2}, {2, 2, 1}}, expected: []int{1, 1, 2, 2}},
+		{slices: [][]int{{1, 2}, {3}, {3, 1, 3, 3}}, expected: []int{1, 2, 3, 3, 3}},
+	}
+
+	for i, test := range tests {
+		union := BagUnion(test.slices...)
+		if !reflect.DeepEqual(union, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, union)
+		}
+	}
+}
+
+func TestBagIntersection(t *testing.T) {
+	tests := []struct {
+		slices   [][]int
+		expected []int
+	}{
+		{slices: nil, expected: nil},
+		{slices: [][]int{{1, 1, 2}}, expected: []int{1, 1, 2}},
+		{slices: [][]int{{1, 1, 2}, {}}, expected: []int{}},
+		{slices: [][]int{{1, 2, 1, 3, 1}, {1, 1, 3, 3}}, expected: []int{1, 1, 3}},
+		{slices: [][]int{{1, 2, 1, 3, 1}, {1, 1, 3, 3}, {3, 1}}, expected: []int{1, 3}},
+	}
+
+	for i, test := range tests {
+		inter := BagIntersection(test.slices...)
+		if !reflect.DeepEqual(inter, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, inter)
+		}
+	}
+}
+
+func TestBagPartition(t *testing.T) {
+	tests := []struct {
+		s1, s2          []int
+		d12, inter, d21 []int
+	}{
+		{s1: nil, s2: nil, d12: []int{}, inter: []int{}, d21: []int{}},
+		{s1: []int{1, 1}, s2: nil, d12: []int{1, 1}, inter: []int{}, d21: []int{}},
+		{s1: []int{1, 2, 1, 3, 1}, s2: []int{3, 1, 3, 1}, d12: []int{2, 1}, inter: []int{1, 1, 3}, d21: []int{3}},
+	}
+
+	for i, test := range tests {
+		d12, inter, d21 := BagPartition(test.s1, test.s2)
+		if !reflect.DeepEqual(d12, test.d12) || !reflect.DeepEqual(inter, test.inter) || !reflect.DeepEqual(d21, test.d21) {
+			t.Errorf("test %d: expected (%v,%v,%v), got (%v,%v,%v)", i, test.d12, test.inter, test.d21, d12, inter, d21)
+		}
+
+		if diff := BagDifference(test.s1, test.s2); !reflect.DeepEqual(diff, test.d12) {
+			t.Errorf("test %d: BagDifference expected %v, got %v", i, test.d12, diff)
+		}
+
+		expected := append(slices.Clone(test.d12), test.d21...)
+		if symDiff := BagSymmetricDifference(test.s1, test.s2); !reflect.DeepEqual(symDiff, expected) {
+			t.Errorf("test %d: BagSymmetricDifference expected %v, got %v", i, expected, symDiff)
+		}
+	}
+}
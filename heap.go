@@ -0,0 +1,188 @@
+package slicex
+
+import "cmp"
+
+// Thresholds for choosing between the linear scan of the window and a binary heap
+// in the k-element selection. See the crossover benchmarks in bench.md.
+const (
+	// linearScanMinK is the k up to which the linear scan is always used.
+	linearScanMinK = 8
+
+	// linearScanMaxK is the k above which the binary heap is always used.
+	linearScanMaxK = 16
+
+	// linearScanRatio is the minimal n/k for which the linear scan is used when k is
+	// between linearScanMinK and linearScanMaxK.
+	linearScanRatio = 1024
+)
+
+// useHeap reports whether selecting k elements out of n should use a binary heap.
+//
+// The linear scan costs O(k) per replacement, while the heap costs O(log k) but has
+// a worse constant. On random input there are about k·ln(n/k) replacements, so when
+// n/k is large the initial pass over the slice dominates and the cheaper linear scan wins.
+func useHeap(k, n int) bool {
+	switch {
+	case k <= linearScanMinK:
+		return false
+	case k > linearScanMaxK:
+		return true
+	default:
+		return n/k < linearScanRatio
+	}
+}
+
+// heapifyMax arranges h into a max-heap, where h[0] is the biggest element.
+func heapifyMax[E cmp.Ordered](h []E) {
+	for i := len(h)/2 - 1; i >= 0; i-- {
+		siftDownMax(h, i)
+	}
+}
+
+// heapifyMin arranges h into a min-heap, where h[0] is the smallest element.
+func heapifyMin[E cmp.Ordered](h []E) {
+	for i := len(h)/2 - 1; i >= 0; i-- {
+		siftDownMin(h, i)
+	}
+}
+
+// siftDownMax restores the max-heap invariant of h starting from position i.
+func siftDownMax[E cmp.Ordered](h []E, i int) {
+	n := len(h)
+	e := h[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+
+		if right := child + 1; right < n && h[right] > h[child] {
+			child = right
+		}
+
+		if !(h[child] > e) {
+			break
+		}
+
+		h[i] = h[child]
+		i = child
+	}
+	h[i] = e
+}
+
+// siftDownMin restores the min-heap invariant of h starting from position i.
+func siftDownMin[E cmp.Ordered](h []E, i int) {
+	n := len(h)
+	e := h[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+
+		if right := child + 1; right < n && h[right] < h[child] {
+			child = right
+		}
+
+		if !(h[child] < e) {
+			break
+		}
+
+		h[i] = h[child]
+		i = child
+	}
+	h[i] = e
+}
+
+// heapifyFunc arranges h into a max-heap according to cmp, where h[0] is the biggest element.
+func heapifyFunc[E any](h []E, cmp func(a, b E) int) {
+	for i := len(h)/2 - 1; i >= 0; i-- {
+		siftDownFunc(h, i, cmp)
+	}
+}
+
+// siftDownFunc restores the max-heap invariant of h according to cmp, starting from position i.
+func siftDownFunc[E any](h []E, i int, cmp func(a, b E) int) {
+	n := len(h)
+	e := h[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+
+		if right := child + 1; right < n && cmp(h[right], h[child]) > 0 {
+			child = right
+		}
+
+		if cmp(h[child], e) <= 0 {
+			break
+		}
+
+		h[i] = h[child]
+		i = child
+	}
+	h[i] = e
+}
+
+// heapifyMax arranges p into a max-heap by value, where p[0] has the biggest value.
+func (p Pairs[K, V]) heapifyMax() {
+	for i := len(p)/2 - 1; i >= 0; i-- {
+		p.siftDownMax(i)
+	}
+}
+
+// heapifyMin arranges p into a min-heap by value, where p[0] has the smallest value.
+func (p Pairs[K, V]) heapifyMin() {
+	for i := len(p)/2 - 1; i >= 0; i-- {
+		p.siftDownMin(i)
+	}
+}
+
+// siftDownMax restores the max-heap invariant of p starting from position i.
+func (p Pairs[K, V]) siftDownMax(i int) {
+	n := len(p)
+	e := p[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+
+		if right := child + 1; right < n && p[right].Val > p[child].Val {
+			child = right
+		}
+
+		if !(p[child].Val > e.Val) {
+			break
+		}
+
+		p[i] = p[child]
+		i = child
+	}
+	p[i] = e
+}
+
+// siftDownMin restores the min-heap invariant of p starting from position i.
+func (p Pairs[K, V]) siftDownMin(i int) {
+	n := len(p)
+	e := p[i]
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+
+		if right := child + 1; right < n && p[right].Val < p[child].Val {
+			child = right
+		}
+
+		if !(p[child].Val < e.Val) {
+			break
+		}
+
+		p[i] = p[child]
+		i = child
+	}
+	p[i] = e
+}
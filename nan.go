@@ -0,0 +1,298 @@
+package slicex
+
+import (
+	"cmp"
+	"slices"
+)
+
+// This file contains the NaN-aware variants of the selections and sorting. The other functions
+// compare elements with < and >, for which NaN is neither smaller nor bigger than any value,
+// so a NaN in the input can end up anywhere in their results.
+// Only floating-point values can be NaN, so for other types these functions behave like the others.
+
+// NaNPolicy defines how the NaN-aware functions handle NaN values.
+// Positive and negative infinities are ordinary values, and they are not affected.
+type NaNPolicy int
+
+const (
+	// NaNIgnore leaves NaNs out of the results, as if they were not in the input.
+	NaNIgnore NaNPolicy = iota
+
+	// NaNSmallest treats NaNs as smaller than any other value, including -Inf, as [slices.Sort] does.
+	NaNSmallest
+
+	// NaNLargest treats NaNs as bigger than any other value, including +Inf.
+	NaNLargest
+)
+
+// MinNaN returns the position and value of the minimal element in s, handling NaNs according
+// to the policy. If there are multiple minimal elements, it returns the first one.
+// It panics if s is empty, or if the policy is [NaNIgnore] and all elements are NaN.
+func MinNaN[E cmp.Ordered](s []E, policy NaNPolicy) (int, E) {
+	if len(s) == 0 {
+		panic("slicex.MinNaN: empty slice")
+	}
+
+	nan, min := scanNaN(s, identity[E], func(a, b E) bool { return a < b })
+	i := pickNaN(nan, min, policy, policy == NaNSmallest, "slicex.MinNaN: all elements are NaN")
+	return i, s[i]
+}
+
+// MaxNaN returns the position and value of the maximal element in s, handling NaNs according
+// to the policy. If there are multiple maximal elements, it returns the first one.
+// It panics if s is empty, or if the policy is [NaNIgnore] and all elements are NaN.
+func MaxNaN[E cmp.Ordered](s []E, policy NaNPolicy) (int, E) {
+	if len(s) == 0 {
+		panic("slicex.MaxNaN: empty slice")
+	}
+
+	nan, max := scanNaN(s, identity[E], func(a, b E) bool { return a > b })
+	i := pickNaN(nan, max, policy, policy == NaNLargest, "slicex.MaxNaN: all elements are NaN")
+	return i, s[i]
+}
+
+// MinKNaN returns a new slice with the k smallest elements in s, sorted in ascending order
+// and handling NaNs according to the policy. With [NaNIgnore], the result has fewer than k elements
+// if s has fewer than k elements that are not NaN. As in [MinKCopy], the original slice is never written to.
+func MinKNaN[E cmp.Ordered](s []E, k int, policy NaNPolicy) []E {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	mins, nans := selectNumbers(s, k, minK[E], identity[E])
+	slices.Sort(mins)
+	return withNaNs(mins, nans, k, policy, policy == NaNSmallest)
+}
+
+// MaxKNaN returns a new slice with the k biggest elements in s, sorted in descending order
+// and handling NaNs according to the policy. With [NaNIgnore], the result has fewer than k elements
+// if s has fewer than k elements that are not NaN. As in [MaxKCopy], the original slice is never written to.
+func MaxKNaN[E cmp.Ordered](s []E, k int, policy NaNPolicy) []E {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	maxs, nans := selectNumbers(s, k, maxK[E], identity[E])
+	SortDescending(maxs)
+	return withNaNs(maxs, nans, k, policy, policy == NaNLargest)
+}
+
+// SortAscendingNaN sorts s in ascending order, handling NaNs according to the policy, and returns it.
+// With [NaNIgnore], the NaNs are moved to the end of s and left out of the result, which is s[:len(s)-nans].
+func SortAscendingNaN[E cmp.Ordered](s []E, policy NaNPolicy) []E {
+	numbers, sorted := splitNaNs(s, identity[E], policy, policy == NaNSmallest)
+	slices.Sort(numbers)
+	return sorted
+}
+
+// SortDescendingNaN sorts s in descending order, handling NaNs according to the policy, and returns it.
+// With [NaNIgnore], the NaNs are moved to the end of s and left out of the result, which is s[:len(s)-nans].
+func SortDescendingNaN[E cmp.Ordered](s []E, policy NaNPolicy) []E {
+	numbers, sorted := splitNaNs(s, identity[E], policy, policy == NaNLargest)
+	SortDescending(numbers)
+	return sorted
+}
+
+// MinNaN returns the minimal pair and its position, handling NaN values according to the policy.
+// It panics if p is empty, or if the policy is [NaNIgnore] and all values are NaN.
+func (p Pairs[K, V]) MinNaN(policy NaNPolicy) (int, Pair[K, V]) {
+	if len(p) == 0 {
+		panic("slicex.MinNaN: pairs is empty")
+	}
+
+	nan, min := scanNaN(p, Pair[K, V].value, func(a, b V) bool { return a < b })
+	i := pickNaN(nan, min, policy, policy == NaNSmallest, "slicex.MinNaN: all values are NaN")
+	return i, p[i]
+}
+
+// MaxNaN returns the maximal pair and its position, handling NaN values according to the policy.
+// It panics if p is empty, or if the policy is [NaNIgnore] and all values are NaN.
+func (p Pairs[K, V]) MaxNaN(policy NaNPolicy) (int, Pair[K, V]) {
+	if len(p) == 0 {
+		panic("slicex.MaxNaN: pairs is empty")
+	}
+
+	nan, max := scanNaN(p, Pair[K, V].value, func(a, b V) bool { return a > b })
+	i := pickNaN(nan, max, policy, policy == NaNLargest, "slicex.MaxNaN: all values are NaN")
+	return i, p[i]
+}
+
+// MinKNaN returns new pairs with the k smallest pairs by value, sorted in ascending order
+// and handling NaN values according to the policy. As in [Pairs.MinKCopy], the original pairs are never written to.
+func (p Pairs[K, V]) MinKNaN(k int, policy NaNPolicy) Pairs[K, V] {
+	if k < 1 || len(p) == 0 {
+		return nil
+	}
+
+	mins, nans := selectNumbers(p, k, Pairs[K, V].minK, Pair[K, V].value)
+	mins.SortAscending()
+	return withNaNs(mins, nans, k, policy, policy == NaNSmallest)
+}
+
+// MaxKNaN returns new pairs with the k biggest pairs by value, sorted in descending order
+// and handling NaN values according to the policy. As in [Pairs.MaxKCopy], the original pairs are never written to.
+func (p Pairs[K, V]) MaxKNaN(k int, policy NaNPolicy) Pairs[K, V] {
+	if k < 1 || len(p) == 0 {
+		return nil
+	}
+
+	maxs, nans := selectNumbers(p, k, Pairs[K, V].maxK, Pair[K, V].value)
+	maxs.SortDescending()
+	return withNaNs(maxs, nans, k, policy, policy == NaNLargest)
+}
+
+// SortAscendingNaN sorts the pairs in ascending order, handling NaN values according to the policy, and returns them.
+// With [NaNIgnore], the pairs with NaN values are moved to the end and left out of the result.
+func (p Pairs[K, V]) SortAscendingNaN(policy NaNPolicy) Pairs[K, V] {
+	numbers, sorted := splitNaNs(p, Pair[K, V].value, policy, policy == NaNSmallest)
+	numbers.SortAscending()
+	return sorted
+}
+
+// SortDescendingNaN sorts the pairs in descending order, handling NaN values according to the policy, and returns them.
+// With [NaNIgnore], the pairs with NaN values are moved to the end and left out of the result.
+func (p Pairs[K, V]) SortDescendingNaN(policy NaNPolicy) Pairs[K, V] {
+	numbers, sorted := splitNaNs(p, Pair[K, V].value, policy, policy == NaNLargest)
+	numbers.SortDescending()
+	return sorted
+}
+
+// isNaN reports whether v is NaN, which is the only value that is not equal to itself.
+func isNaN[V cmp.Ordered](v V) bool { return v != v }
+
+// scanNaN returns the position of the first NaN in s, and the position of the first of the
+// best other elements according to better. Positions are -1 if there are no such elements.
+func scanNaN[S ~[]E, E any, V cmp.Ordered](s S, val func(E) V, better func(a, b V) bool) (nan, best int) {
+	nan, best = -1, -1
+	for i, e := range s {
+		v := val(e)
+		switch {
+		case isNaN(v):
+			if nan == -1 {
+				nan = i
+			}
+
+		case best == -1 || better(v, val(s[best])):
+			best = i
+		}
+	}
+	return nan, best
+}
+
+// pickNaN returns the position of the first NaN or of the best other element, according to the policy.
+// nanWins reports whether NaNs are better than the other elements. It panics with msg if there is none to return.
+func pickNaN(nan, best int, policy NaNPolicy, nanWins bool, msg string) int {
+	switch {
+	case nan != -1 && policy != NaNIgnore && (nanWins || best == -1):
+		return nan
+
+	case best != -1:
+		return best
+
+	default:
+		panic(msg)
+	}
+}
+
+// selectNumbers returns the k best elements of s that are not NaN, in no particular order, and the first k NaNs.
+// It fills a window with the first k elements, and then feeds the runs of elements between NaNs to the selection.
+// Runs shorter than k are batched together, so that the selection is not called too often.
+func selectNumbers[S ~[]E, E any, V cmp.Ordered](s S, k int, selection func(window, rest S), val func(E) V) (window, nans S) {
+	var batch S
+	for i := 0; i < len(s); {
+		if isNaN(val(s[i])) {
+			if len(nans) < k {
+				nans = append(nans, s[i])
+			}
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < len(s) && !isNaN(val(s[j])) {
+			j++
+		}
+
+		run := s[i:j]
+		i = j
+
+		if len(window) < k {
+			if window == nil {
+				window = make(S, 0, min(k, len(s)))
+			}
+
+			n := min(k-len(window), len(run))
+			window = append(window, run[:n]...)
+			run = run[n:]
+		}
+
+		switch {
+		case len(run) >= k:
+			selection(window, run)
+
+		case len(run) > 0:
+			if batch == nil {
+				batch = make(S, 0, 2*k)
+			}
+
+			batch = append(batch, run...)
+			if len(batch) >= k {
+				selection(window, batch)
+				batch = batch[:0]
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		selection(window, batch)
+	}
+	return window, nans
+}
+
+// withNaNs returns the first k of the sorted numbers and the NaNs, which come first if first is true.
+// With [NaNIgnore], it returns the numbers.
+func withNaNs[S ~[]E, E any](numbers, nans S, k int, policy NaNPolicy, first bool) S {
+	if policy == NaNIgnore || len(nans) == 0 {
+		return numbers
+	}
+
+	result := make(S, 0, min(k, len(numbers)+len(nans)))
+	if first {
+		result = append(result, nans[:min(k, len(nans))]...)
+		return append(result, numbers[:min(len(numbers), k-len(result))]...)
+	}
+
+	result = append(result, numbers...)
+	return append(result, nans[:min(len(nans), k-len(result))]...)
+}
+
+// splitNaNs moves the NaNs of s to its start if first is true, otherwise to its end.
+// It returns the part of s with the other elements, and the part of s that is the result of the sorting,
+// which is s itself unless the NaNs are ignored.
+func splitNaNs[S ~[]E, E any, V cmp.Ordered](s S, val func(E) V, policy NaNPolicy, first bool) (numbers, sorted S) {
+	first = first && policy != NaNIgnore
+	nans := 0
+
+	if first {
+		for i, e := range s {
+			if isNaN(val(e)) {
+				s[i], s[nans] = s[nans], s[i]
+				nans++
+			}
+		}
+		return s[nans:], s
+	}
+
+	for i := len(s) - 1; i >= 0; i-- {
+		if isNaN(val(s[i])) {
+			nans++
+			s[i], s[len(s)-nans] = s[len(s)-nans], s[i]
+		}
+	}
+
+	numbers = s[:len(s)-nans]
+	if policy == NaNIgnore {
+		return numbers, numbers
+	}
+	return numbers, s
+}
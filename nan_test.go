@@ -0,0 +1,185 @@
+package slicex
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+var (
+	nan  = math.NaN()
+	inf  = math.Inf(1)
+	ninf = math.Inf(-1)
+)
+
+// equalNaN reports whether the slices are equal, considering NaNs equal to each other.
+func equalNaN(s1, s2 []float64) bool {
+	return len(s1) == len(s2) && slices.EqualFunc(s1, s2, func(a, b float64) bool { return a == b || (isNaN(a) && isNaN(b)) })
+}
+
+func TestMinMaxNaN(t *testing.T) {
+	tests := []struct {
+		s                  []float64
+		policy             NaNPolicy
+		minIndex, maxIndex int
+	}{
+		{s: []float64{nan, 1, 2}, policy: NaNIgnore, minIndex: 1, maxIndex: 2},
+		{s: []float64{nan, 1, 2}, policy: NaNSmallest, minIndex: 0, maxIndex: 2},
+		{s: []float64{nan, 1, 2}, policy: NaNLargest, minIndex: 1, maxIndex: 0},
+		{s: []float64{1, inf, nan, ninf, nan}, policy: NaNIgnore, minIndex: 3, maxIndex: 1},
+		{s: []float64{1, inf, nan, ninf, nan}, policy: NaNSmallest, minIndex: 2, maxIndex: 1},
+		{s: []float64{1, inf, nan, ninf, nan}, policy: NaNLargest, minIndex: 3, maxIndex: 2},
+		{s: []float64{nan, nan}, policy: NaNSmallest, minIndex: 0, maxIndex: 0},
+		{s: []float64{nan, nan}, policy: NaNLargest, minIndex: 0, maxIndex: 0},
+		{s: []float64{2, 1, 1, 2}, policy: NaNLargest, minIndex: 1, maxIndex: 0},
+	}
+
+	for i, test := range tests {
+		if min, _ := MinNaN(test.s, test.policy); min != test.minIndex {
+			t.Errorf("test %d: MinNaN expected %d, got %d", i, test.minIndex, min)
+		}
+
+		if max, _ := MaxNaN(test.s, test.policy); max != test.maxIndex {
+			t.Errorf("test %d: MaxNaN expected %d, got %d", i, test.maxIndex, max)
+		}
+
+		p := toPairs(test.s)
+		if min, _ := p.MinNaN(test.policy); min != test.minIndex {
+			t.Errorf("test %d: Pairs.MinNaN expected %d, got %d", i, test.minIndex, min)
+		}
+
+		if max, _ := p.MaxNaN(test.policy); max != test.maxIndex {
+			t.Errorf("test %d: Pairs.MaxNaN expected %d, got %d", i, test.maxIndex, max)
+		}
+	}
+
+	for _, s := range [][]float64{nil, {nan, nan}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %v", s)
+				}
+			}()
+			MinNaN(s, NaNIgnore)
+		}()
+	}
+}
+
+func TestMinKNaN(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		s := []float64{3, nan, inf, 1, nan, ninf, 2}
+		tests := []struct {
+			k          int
+			policy     NaNPolicy
+			mins, maxs []float64
+		}{
+			{k: 0, policy: NaNSmallest, mins: nil, maxs: nil},
+			{k: 3, policy: NaNIgnore, mins: []float64{ninf, 1, 2}, maxs: []float64{inf, 3, 2}},
+			{k: 10, policy: NaNIgnore, mins: []float64{ninf, 1, 2, 3, inf}, maxs: []float64{inf, 3, 2, 1, ninf}},
+			{k: 3, policy: NaNSmallest, mins: []float64{nan, nan, ninf}, maxs: []float64{inf, 3, 2}},
+			{k: 10, policy: NaNSmallest, mins: []float64{nan, nan, ninf, 1, 2, 3, inf}, maxs: []float64{inf, 3, 2, 1, ninf, nan, nan}},
+			{k: 3, policy: NaNLargest, mins: []float64{ninf, 1, 2}, maxs: []float64{nan, nan, inf}},
+			{k: 6, policy: NaNLargest, mins: []float64{ninf, 1, 2, 3, inf, nan}, maxs: []float64{nan, nan, inf, 3, 2, 1}},
+		}
+
+		for i, test := range tests {
+			original := slices.Clone(s)
+			if mins := MinKNaN(s, test.k, test.policy); !equalNaN(mins, test.mins) {
+				t.Errorf("test %d: MinKNaN expected %v, got %v", i, test.mins, mins)
+			}
+
+			if maxs := MaxKNaN(s, test.k, test.policy); !equalNaN(maxs, test.maxs) {
+				t.Errorf("test %d: MaxKNaN expected %v, got %v", i, test.maxs, maxs)
+			}
+
+			if !equalNaN(s, original) {
+				t.Fatalf("test %d: the original slice was modified", i)
+			}
+
+			p := toPairs(s)
+			if mins := p.MinKNaN(test.k, test.policy); !equalNaN(mins.Vals(), test.mins) {
+				t.Errorf("test %d: Pairs.MinKNaN expected %v, got %v", i, test.mins, mins.Vals())
+			}
+
+			if maxs := p.MaxKNaN(test.k, test.policy); !equalNaN(maxs.Vals(), test.maxs) {
+				t.Errorf("test %d: Pairs.MaxKNaN expected %v, got %v", i, test.maxs, maxs.Vals())
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			s := RandomFloats(rand.IntN(1000))
+			for range rand.IntN(100) {
+				if len(s) > 0 {
+					s[rand.IntN(len(s))] = nan
+				}
+			}
+			k := rand.IntN(200)
+
+			for _, policy := range []NaNPolicy{NaNIgnore, NaNSmallest, NaNLargest} {
+				sorted := SortAscendingNaN(slices.Clone(s), policy)
+				expected := sorted[:min(k, len(sorted))]
+				if k == 0 || len(sorted) == 0 {
+					expected = nil
+				}
+
+				if mins := MinKNaN(s, k, policy); !equalNaN(mins, expected) {
+					t.Fatalf("policy %d, k=%d: expected %v, got %v", policy, k, expected, mins)
+				}
+
+				sorted = SortDescendingNaN(slices.Clone(s), policy)
+				expected = sorted[:min(k, len(sorted))]
+				if k == 0 || len(sorted) == 0 {
+					expected = nil
+				}
+
+				if maxs := MaxKNaN(s, k, policy); !equalNaN(maxs, expected) {
+					t.Fatalf("policy %d, k=%d: expected %v, got %v", policy, k, expected, maxs)
+				}
+			}
+		}
+	})
+}
+
+func TestSortNaN(t *testing.T) {
+	s := []float64{3, nan, inf, 1, nan, ninf, 2}
+	tests := []struct {
+		policy                NaNPolicy
+		ascending, descending []float64
+	}{
+		{policy: NaNIgnore, ascending: []float64{ninf, 1, 2, 3, inf}, descending: []float64{inf, 3, 2, 1, ninf}},
+		{policy: NaNSmallest, ascending: []float64{nan, nan, ninf, 1, 2, 3, inf}, descending: []float64{inf, 3, 2, 1, ninf, nan, nan}},
+		{policy: NaNLargest, ascending: []float64{ninf, 1, 2, 3, inf, nan, nan}, descending: []float64{nan, nan, inf, 3, 2, 1, ninf}},
+	}
+
+	for i, test := range tests {
+		if sorted := SortAscendingNaN(slices.Clone(s), test.policy); !equalNaN(sorted, test.ascending) {
+			t.Errorf("test %d: SortAscendingNaN expected %v, got %v", i, test.ascending, sorted)
+		}
+
+		if sorted := SortDescendingNaN(slices.Clone(s), test.policy); !equalNaN(sorted, test.descending) {
+			t.Errorf("test %d: SortDescendingNaN expected %v, got %v", i, test.descending, sorted)
+		}
+
+		if sorted := toPairs(s).SortAscendingNaN(test.policy); !equalNaN(sorted.Vals(), test.ascending) {
+			t.Errorf("test %d: Pairs.SortAscendingNaN expected %v, got %v", i, test.ascending, sorted.Vals())
+		}
+
+		if sorted := toPairs(s).SortDescendingNaN(test.policy); !equalNaN(sorted.Vals(), test.descending) {
+			t.Errorf("test %d: Pairs.SortDescendingNaN expected %v, got %v", i, test.descending, sorted.Vals())
+		}
+	}
+}
+
+func BenchmarkMaxKNaN(b *testing.B) {
+	for _, bench := range SortBenchs {
+		b.Run(fmt.Sprintf("max_10/%d", len(bench)), func(b *testing.B) {
+			for range b.N {
+				MaxKNaN(bench, 10, NaNIgnore)
+			}
+		})
+	}
+}
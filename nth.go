@@ -0,0 +1,259 @@
+package slicex
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+)
+
+// NthElement rearranges s so that s[n] is the element that would be in that position if s was sorted,
+// like C++ std::nth_element. The elements before s[n] are smaller or equal to it, and the ones after it
+// are bigger or equal, both in no particular order. NaNs are smaller than any other value, as in [slices.Sort].
+//
+// It uses introselect: quickselect with a three-way partition, which falls back to a heap selection
+// when the partitions are unbalanced, so it runs in O(len(s)) on average and O(len(s) log n) in the worst case.
+// It panics if n is not a valid position in s.
+func NthElement[E cmp.Ordered](s []E, n int) {
+	if n < 0 || n >= len(s) {
+		panic("slicex.NthElement: n out of range")
+	}
+
+	nans := nansFirst(s)
+	if n >= nans {
+		introselect(s[nans:], n-nans)
+	}
+}
+
+// NthElementFunc is like [NthElement], but it compares elements with the cmp function,
+// which follows the [slices.SortFunc] convention.
+func NthElementFunc[E any](s []E, n int, cmp func(a, b E) int) {
+	if n < 0 || n >= len(s) {
+		panic("slicex.NthElementFunc: n out of range")
+	}
+	introselectFunc(s, n, cmp)
+}
+
+// PartialSort rearranges s so that s[:k] holds the k smallest elements, sorted in ascending order,
+// like C++ std::partial_sort. The elements of s[k:] are in no particular order.
+// Unlike [MinK], s remains a permutation of the original slice. If k is bigger than len(s), all of s is sorted.
+// NaNs are smaller than any other value, as in [slices.Sort].
+func PartialSort[E cmp.Ordered](s []E, k int) {
+	if k < 1 {
+		return
+	}
+
+	if k < len(s) {
+		nans := nansFirst(s)
+		if k > nans {
+			introselect(s[nans:], k-1-nans)
+		}
+	}
+	slices.Sort(s[:min(k, len(s))])
+}
+
+// PartialSortFunc is like [PartialSort], but it compares elements with the cmp function,
+// which follows the [slices.SortFunc] convention.
+func PartialSortFunc[E any](s []E, k int, cmp func(a, b E) int) {
+	if k < 1 {
+		return
+	}
+
+	if k < len(s) {
+		introselectFunc(s, k-1, cmp)
+	}
+	slices.SortFunc(s[:min(k, len(s))], cmp)
+}
+
+const (
+	// smallSelect is the size under which introselect sorts the remaining part of the slice.
+	smallSelect = 12
+
+	// heapSelectRatio is the minimum len(s)/(n+1) for which [heapSelect] is faster than
+	// the partitions of introselect, like when selecting the 100 smallest of 10000 elements.
+	heapSelectRatio = 100
+)
+
+// nansFirst moves the NaNs of s to its start, where they are when s is sorted, and returns how many they are.
+// The selections compare elements with < and >, so they must only see the other elements.
+func nansFirst[E cmp.Ordered](s []E) int {
+	nans := 0
+	for i, e := range s {
+		if isNaN(e) {
+			s[i], s[nans] = s[nans], s[i]
+			nans++
+		}
+	}
+	return nans
+}
+
+// introselect rearranges s so that s[n] is the element that would be there if s was sorted.
+// Each partition should halve the part of s that contains n, so when that fails for more than
+// 2·log2(len(s)) partitions, it falls back to [heapSelect].
+func introselect[E cmp.Ordered](s []E, n int) {
+	if (n+1)*heapSelectRatio <= len(s) {
+		heapSelect(s, n)
+		return
+	}
+
+	lo, hi := 0, len(s)
+	budget := 2 * bits.Len(uint(len(s)))
+
+	for hi-lo > smallSelect {
+		if budget == 0 {
+			heapSelect(s[lo:hi], n-lo)
+			return
+		}
+		budget--
+
+		lt, gt := partition3(s[lo:hi], medianOfThree(s[lo:hi]))
+		switch {
+		case n < lo+lt:
+			hi = lo + lt
+		case n >= lo+gt:
+			lo = lo + gt
+		default:
+			// s[n] is equal to the pivot, which is in its sorted position
+			return
+		}
+	}
+	slices.Sort(s[lo:hi])
+}
+
+// partition3 rearranges s into the elements smaller than the pivot, the ones equal to it,
+// and the ones bigger than it. It returns the bounds of the equal elements, s[lt:gt].
+func partition3[E cmp.Ordered](s []E, pivot E) (lt, gt int) {
+	i := 0
+	lt, gt = 0, len(s)
+
+	for i < gt {
+		switch {
+		case s[i] < pivot:
+			s[lt], s[i] = s[i], s[lt]
+			lt++
+			i++
+
+		case s[i] > pivot:
+			gt--
+			s[i], s[gt] = s[gt], s[i]
+
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
+
+// medianOfThree returns the median of the first, middle and last elements of s.
+func medianOfThree[E cmp.Ordered](s []E) E {
+	a, b, c := s[0], s[len(s)/2], s[len(s)-1]
+	if a > b {
+		a, b = b, a
+	}
+	if b > c {
+		b = c
+	}
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// heapSelect rearranges s so that s[n] is the element that would be there if s was sorted,
+// in O(len(s) log n). It keeps the n+1 smallest elements in a max-heap at the start of s, as in [MinK],
+// but it swaps out the replaced elements so that s remains a permutation.
+func heapSelect[E cmp.Ordered](s []E, n int) {
+	mins := s[:n+1]
+	heapifyMax(mins)
+
+	for i := n + 1; i < len(s); i++ {
+		if s[i] < mins[0] {
+			mins[0], s[i] = s[i], mins[0]
+			siftDownMax(mins, 0)
+		}
+	}
+
+	// the biggest of the n+1 smallest elements is the n-th one
+	mins[0], mins[n] = mins[n], mins[0]
+}
+
+// introselectFunc is like [introselect], but it compares elements with the cmp function.
+func introselectFunc[E any](s []E, n int, cmp func(a, b E) int) {
+	if (n+1)*heapSelectRatio <= len(s) {
+		heapSelectFunc(s, n, cmp)
+		return
+	}
+
+	lo, hi := 0, len(s)
+	budget := 2 * bits.Len(uint(len(s)))
+
+	for hi-lo > smallSelect {
+		if budget == 0 {
+			heapSelectFunc(s[lo:hi], n-lo, cmp)
+			return
+		}
+		budget--
+
+		lt, gt := partition3Func(s[lo:hi], medianOfThreeFunc(s[lo:hi], cmp), cmp)
+		switch {
+		case n < lo+lt:
+			hi = lo + lt
+		case n >= lo+gt:
+			lo = lo + gt
+		default:
+			return
+		}
+	}
+	slices.SortFunc(s[lo:hi], cmp)
+}
+
+// partition3Func is like [partition3], but it compares elements with the cmp function.
+func partition3Func[E any](s []E, pivot E, cmp func(a, b E) int) (lt, gt int) {
+	i := 0
+	lt, gt = 0, len(s)
+
+	for i < gt {
+		switch c := cmp(s[i], pivot); {
+		case c < 0:
+			s[lt], s[i] = s[i], s[lt]
+			lt++
+			i++
+
+		case c > 0:
+			gt--
+			s[i], s[gt] = s[gt], s[i]
+
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
+
+// medianOfThreeFunc is like [medianOfThree], but it compares elements with the cmp function.
+func medianOfThreeFunc[E any](s []E, cmp func(a, b E) int) E {
+	a, b, c := s[0], s[len(s)/2], s[len(s)-1]
+	if cmp(a, b) > 0 {
+		a, b = b, a
+	}
+	if cmp(b, c) > 0 {
+		b = c
+	}
+	if cmp(a, b) > 0 {
+		return a
+	}
+	return b
+}
+
+// heapSelectFunc is like [heapSelect], but it compares elements with the cmp function.
+func heapSelectFunc[E any](s []E, n int, cmp func(a, b E) int) {
+	mins := s[:n+1]
+	heapifyFunc(mins, cmp)
+
+	for i := n + 1; i < len(s); i++ {
+		if cmp(s[i], mins[0]) < 0 {
+			mins[0], s[i] = s[i], mins[0]
+			siftDownFunc(mins, 0, cmp)
+		}
+	}
+	mins[0], mins[n] = mins[n], mins[0]
+}
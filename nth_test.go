@@ -0,0 +1,265 @@
+package slicex
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// isNth reports whether s[n] is in its sorted position, with smaller or equal elements
+// before it and bigger or equal elements after it, as in [cmp.Less].
+func isNth[E cmp.Ordered](s []E, n int) bool {
+	for _, e := range s[:n] {
+		if cmp.Less(s[n], e) {
+			return false
+		}
+	}
+
+	for _, e := range s[n+1:] {
+		if cmp.Less(e, s[n]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNthElement(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			s        []int
+			n        int
+			expected int
+		}{
+			{s: []int{1}, n: 0, expected: 1},
+			{s: []int{3, 1, 2}, n: 0, expected: 1},
+			{s: []int{3, 1, 2}, n: 2, expected: 3},
+			{s: []int{5, 5, 1, 5, 2}, n: 3, expected: 5},
+		}
+
+		for i, test := range tests {
+			NthElement(test.s, test.n)
+			if test.s[test.n] != test.expected || !isNth(test.s, test.n) {
+				t.Errorf("test %d: expected %d, got %v", i, test.expected, test.s)
+			}
+		}
+
+		for _, n := range []int{-1, 3} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("expected panic for n=%d", n)
+					}
+				}()
+				NthElement([]int{1, 2, 3}, n)
+			}()
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 1000 {
+			size := rand.IntN(1000) + 1
+			s := RandomInts(size, 1+rand.IntN(2*size)) // including many duplicates
+			n := rand.IntN(size)
+
+			expected := slices.Sorted(slices.Values(s))
+			NthElement(s, n)
+
+			if s[n] != expected[n] || !isNth(s, n) {
+				t.Fatalf("n=%d: expected %d, got %v", n, expected[n], s)
+			}
+
+			if slices.Sort(s); !slices.Equal(s, expected) {
+				t.Fatalf("the result is not a permutation of the original slice")
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		// NaNs are smaller than any other value, as in slices.Sort
+		for range 1000 {
+			size := rand.IntN(1000) + 1
+			s := randomNaNs(size)
+			n := rand.IntN(size)
+
+			expected := slices.Sorted(slices.Values(s))
+			NthElement(s, n)
+
+			if !equalNaN(s[n:n+1], expected[n:n+1]) || !isNth(s, n) {
+				t.Fatalf("n=%d: expected %v, got %v", n, expected[n], s)
+			}
+
+			if slices.Sort(s); !equalNaN(s, expected) {
+				t.Fatalf("the result is not a permutation of the original slice")
+			}
+		}
+	})
+}
+
+// randomNaNs returns a slice of random floats with duplicates, infinities, and NaNs in a random proportion.
+func randomNaNs(size int) []float64 {
+	density := rand.IntN(101)
+	values := []float64{ninf, inf, 0, 1, 2}
+	s := make([]float64, size)
+	for i := range s {
+		switch {
+		case rand.IntN(100) < density:
+			s[i] = nan
+		case rand.IntN(2) == 0:
+			s[i] = values[rand.IntN(len(values))]
+		default:
+			s[i] = rand.Float64()
+		}
+	}
+	return s
+}
+
+func TestNthElementFunc(t *testing.T) {
+	for range 1000 {
+		size := rand.IntN(1000) + 1
+		s := RandomInts(size, 1+rand.IntN(2*size))
+		n := rand.IntN(size)
+
+		// selecting in descending order
+		expected := slices.Sorted(slices.Values(s))
+		slices.Reverse(expected)
+		NthElementFunc(s, n, descending[int])
+
+		if s[n] != expected[n] {
+			t.Fatalf("n=%d: expected %d, got %v", n, expected[n], s)
+		}
+
+		for _, e := range s[:n] {
+			if e < s[n] {
+				t.Fatalf("n=%d: %d is before %d", n, e, s[n])
+			}
+		}
+	}
+}
+
+func TestHeapSelect(t *testing.T) {
+	// the fallback of introselect, which is hard to trigger with random inputs
+	for range 1000 {
+		size := rand.IntN(1000) + 1
+		s := RandomInts(size, 1+rand.IntN(2*size))
+		n := rand.IntN(size)
+
+		expected := slices.Sorted(slices.Values(s))
+		c := slices.Clone(s)
+		heapSelect(s, n)
+		heapSelectFunc(c, n, cmp.Compare[int])
+
+		if s[n] != expected[n] || !isNth(s, n) {
+			t.Fatalf("n=%d: expected %d, got %v", n, expected[n], s)
+		}
+
+		if c[n] != expected[n] || !isNth(c, n) {
+			t.Fatalf("n=%d: expected %d, got %v", n, expected[n], c)
+		}
+
+		if slices.Sort(s); !slices.Equal(s, expected) {
+			t.Fatalf("the result is not a permutation of the original slice")
+		}
+	}
+}
+
+func TestPartialSort(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			s        []int
+			k        int
+			expected []int
+		}{
+			{s: nil, k: 3, expected: nil},
+			{s: []int{3, 1, 2}, k: 0, expected: []int{3, 1, 2}},
+			{s: []int{3, 1, 2}, k: 1, expected: []int{1}},
+			{s: []int{5, 4, 3, 2, 1}, k: 3, expected: []int{1, 2, 3}},
+			{s: []int{3, 1, 2}, k: 10, expected: []int{1, 2, 3}},
+		}
+
+		for i, test := range tests {
+			PartialSort(test.s, test.k)
+			if prefix := test.s[:min(max(test.k, len(test.expected)), len(test.s))]; !slices.Equal(prefix, test.expected) {
+				t.Errorf("test %d: expected %v, got %v", i, test.expected, prefix)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 1000 {
+			size := rand.IntN(1000)
+			s := RandomInts(size, 1+rand.IntN(2*size+1))
+			k := rand.IntN(size + 10)
+			c := slices.Clone(s)
+
+			expected := slices.Sorted(slices.Values(s))
+			PartialSort(s, k)
+			PartialSortFunc(c, k, cmp.Compare[int])
+
+			k = min(k, size)
+			if !slices.Equal(s[:k], expected[:k]) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected[:k], s[:k])
+			}
+
+			if !slices.Equal(c[:k], expected[:k]) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected[:k], c[:k])
+			}
+
+			if slices.Sort(s); !slices.Equal(s, expected) {
+				t.Fatalf("the result is not a permutation of the original slice")
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		// NaNs are smaller than any other value, as in slices.Sort
+		s := []float64{2, nan, ninf, nan, inf, 1}
+		PartialSort(s, 4)
+		if expected := []float64{nan, nan, ninf, 1}; !equalNaN(s[:4], expected) {
+			t.Errorf("expected %v, got %v", expected, s[:4])
+		}
+
+		for range 1000 {
+			size := rand.IntN(1000)
+			s := randomNaNs(size)
+			k := rand.IntN(size + 10)
+
+			expected := slices.Sorted(slices.Values(s))
+			PartialSort(s, k)
+
+			k = min(k, size)
+			if !equalNaN(s[:k], expected[:k]) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected[:k], s[:k])
+			}
+
+			if slices.Sort(s); !equalNaN(s, expected) {
+				t.Fatalf("the result is not a permutation of the original slice")
+			}
+		}
+	})
+}
+
+func BenchmarkNthElement(b *testing.B) {
+	for _, bench := range SortBenchs {
+		b.Run(fmt.Sprintf("median/%d", len(bench)), func(b *testing.B) {
+			c := make([]float64, len(bench))
+			for range b.N {
+				copy(c, bench)
+				NthElement(c, len(c)/2)
+			}
+		})
+	}
+}
+
+func BenchmarkPartialSort(b *testing.B) {
+	for _, bench := range SortBenchs {
+		b.Run(fmt.Sprintf("min_100/%d", len(bench)), func(b *testing.B) {
+			c := make([]float64, len(bench))
+			for range b.N {
+				copy(c, bench)
+				PartialSort(c, 100)
+			}
+		})
+	}
+}
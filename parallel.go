@@ -0,0 +1,88 @@
+package slicex
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// This file contains the parallel variants of the selections, which split the input in
+// contiguous shards, one for each worker goroutine. The number of workers is an argument,
+// and values smaller than 1 mean runtime.GOMAXPROCS(0). Fewer workers are used when the
+// shards would be too small to be worth a goroutine. The parallel set operations are in parallelset.go.
+
+// minShardSize is the minimum number of elements of a shard, below which
+// starting a goroutine costs more than what it saves.
+const minShardSize = 1 << 14
+
+// numWorkers returns the number of workers to use for n elements.
+func numWorkers(workers, n int) int {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return max(1, min(workers, n/minShardSize))
+}
+
+// parallel splits [0, n) into w contiguous shards of about the same size,
+// and calls f on each of them in a separate goroutine. It returns when all calls have returned.
+func parallel(n, w int, f func(shard, lo, hi int)) {
+	if w == 1 {
+		f(0, 0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(w)
+
+	for i := range w {
+		go func() {
+			defer wg.Done()
+			f(i, i*n/w, (i+1)*n/w)
+		}()
+	}
+	wg.Wait()
+}
+
+// ParallelMinK is like [MinKCopy], but the work is split between the workers.
+// The result is the same as the one of [MinK].
+func ParallelMinK[E cmp.Ordered](s []E, k, workers int) []E {
+	return parallelSelect(s, k, workers, MinKCopy[E])
+}
+
+// ParallelMaxK is like [MaxKCopy], but the work is split between the workers.
+// The result is the same as the one of [MaxK].
+func ParallelMaxK[E cmp.Ordered](s []E, k, workers int) []E {
+	return parallelSelect(s, k, workers, MaxKCopy[E])
+}
+
+// parallelSelect selects the k best elements of each shard with selectK,
+// and then the k best among them.
+func parallelSelect[E cmp.Ordered](s []E, k, workers int, selectK func([]E, int) []E) []E {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	w := numWorkers(workers, len(s))
+	if w == 1 {
+		return selectK(s, k)
+	}
+
+	bests := make([][]E, w)
+	parallel(len(s), w, func(i, lo, hi int) {
+		bests[i] = selectK(s[lo:hi], k)
+	})
+	return selectK(slices.Concat(bests...), k)
+}
+
+// ParallelMinK is like [Pairs.MinKStable], but the work is split between the workers.
+// The result is the same, so it doesn't depend on the number of workers.
+func (p Pairs[K, V]) ParallelMinK(k, workers int) Pairs[K, V] {
+	return p.stableSelect(k, workers, false)
+}
+
+// ParallelMaxK is like [Pairs.MaxKStable], but the work is split between the workers.
+// The result is the same, so it doesn't depend on the number of workers.
+func (p Pairs[K, V]) ParallelMaxK(k, workers int) Pairs[K, V] {
+	return p.stableSelect(k, workers, true)
+}
@@ -0,0 +1,165 @@
+package slicex
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestNumWorkers(t *testing.T) {
+	tests := []struct {
+		workers, n, expected int
+	}{
+		{workers: 4, n: 0, expected: 1},
+		{workers: 4, n: minShardSize - 1, expected: 1},
+		{workers: 4, n: 2 * minShardSize, expected: 2},
+		{workers: 4, n: 100 * minShardSize, expected: 4},
+		{workers: 0, n: 100 * minShardSize, expected: min(100, runtime.GOMAXPROCS(0))},
+	}
+
+	for i, test := range tests {
+		if w := numWorkers(test.workers, test.n); w != test.expected {
+			t.Errorf("test %d: expected %d, got %d", i, test.expected, w)
+		}
+	}
+}
+
+func TestParallelMaxK(t *testing.T) {
+	for range 20 {
+		s := RandomInts(rand.IntN(10*minShardSize), 1000)
+		k := rand.IntN(2000)
+		workers := rand.IntN(10)
+
+		expected := MaxK(slices.Clone(s), k)
+		if maxs := ParallelMaxK(s, k, workers); !slices.Equal(maxs, expected) {
+			t.Fatalf("k=%d, workers=%d: expected %v, got %v", k, workers, expected, maxs)
+		}
+
+		expected = MinK(slices.Clone(s), k)
+		if mins := ParallelMinK(s, k, workers); !slices.Equal(mins, expected) {
+			t.Fatalf("k=%d, workers=%d: expected %v, got %v", k, workers, expected, mins)
+		}
+	}
+}
+
+func TestPairsParallelMaxK(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		p := Pairs[string, int]{{"a", 1}, {"b", 3}, {"c", 2}, {"d", 3}, {"e", 1}, {"f", 2}}
+		tests := []struct {
+			k          int
+			maxs, mins Pairs[string, int]
+		}{
+			{k: 0, maxs: nil, mins: nil},
+			{k: 1, maxs: Pairs[string, int]{{"b", 3}}, mins: Pairs[string, int]{{"a", 1}}},
+			{k: 3, maxs: Pairs[string, int]{{"b", 3}, {"d", 3}, {"c", 2}}, mins: Pairs[string, int]{{"a", 1}, {"e", 1}, {"c", 2}}},
+			{k: 10, maxs: Pairs[string, int]{{"b", 3}, {"d", 3}, {"c", 2}, {"f", 2}, {"a", 1}, {"e", 1}}, mins: Pairs[string, int]{{"a", 1}, {"e", 1}, {"c", 2}, {"f", 2}, {"b", 3}, {"d", 3}}},
+		}
+
+		for i, test := range tests {
+			if maxs := p.ParallelMaxK(test.k, 2); !slices.Equal(maxs, test.maxs) {
+				t.Errorf("test %d: expected %v, got %v", i, test.maxs, maxs)
+			}
+
+			if mins := p.ParallelMinK(test.k, 2); !slices.Equal(mins, test.mins) {
+				t.Errorf("test %d: expected %v, got %v", i, test.mins, mins)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 20 {
+			// few distinct values, so that there are many ties
+			size := rand.IntN(10 * minShardSize)
+			p := Pack(RandomInts(size, 1<<30), RandomInts(size, 100))
+			k := rand.IntN(2000)
+			workers := rand.IntN(10)
+
+			expected := slices.Clone(p)
+			slices.SortStableFunc(expected, func(p1, p2 Pair[int, int]) int { return cmp.Compare(p2.Val, p1.Val) })
+			expected = expected[:min(k, len(p))]
+
+			if maxs := p.ParallelMaxK(k, workers); !slices.Equal(maxs, expected) {
+				t.Fatalf("k=%d, workers=%d: expected %v, got %v", k, workers, expected, maxs)
+			}
+
+			if vals := p.MaxKCopy(k).Vals(); !slices.Equal(vals, expected.Vals()) {
+				t.Fatalf("k=%d, workers=%d: expected %v, got %v", k, workers, expected.Vals(), vals)
+			}
+
+			expected = slices.Clone(p)
+			slices.SortStableFunc(expected, func(p1, p2 Pair[int, int]) int { return cmp.Compare(p1.Val, p2.Val) })
+			expected = expected[:min(k, len(p))]
+
+			if mins := p.ParallelMinK(k, workers); !slices.Equal(mins, expected) {
+				t.Fatalf("k=%d, workers=%d: expected %v, got %v", k, workers, expected, mins)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		for range 20 {
+			// NaNs are smaller than any other value, as in cmp.Compare
+			size := rand.IntN(10 * minShardSize)
+			density := rand.IntN(101)
+			vals := make([]float64, size)
+			for i := range vals {
+				if rand.IntN(100) < density {
+					vals[i] = nan
+				} else {
+					vals[i] = float64(rand.IntN(100))
+				}
+			}
+
+			p := Pack(RandomInts(size, 1<<30), vals)
+			k := rand.IntN(2000)
+			workers := rand.IntN(10)
+
+			expected := slices.Clone(p)
+			slices.SortStableFunc(expected, func(p1, p2 Pair[int, float64]) int { return cmp.Compare(p2.Val, p1.Val) })
+			expected = expected[:min(k, len(p))]
+
+			if maxs := p.ParallelMaxK(k, workers); !slices.Equal(maxs.Keys(), expected.Keys()) {
+				t.Fatalf("k=%d, workers=%d: expected %v, got %v", k, workers, expected, maxs)
+			}
+
+			expected = slices.Clone(p)
+			slices.SortStableFunc(expected, func(p1, p2 Pair[int, float64]) int { return cmp.Compare(p1.Val, p2.Val) })
+			expected = expected[:min(k, len(p))]
+
+			if mins := p.ParallelMinK(k, workers); !slices.Equal(mins.Keys(), expected.Keys()) {
+				t.Fatalf("k=%d, workers=%d: expected %v, got %v", k, workers, expected, mins)
+			}
+		}
+	})
+}
+
+// ParallelBenchSize is the size of the inputs of the parallel benchmarks, which are
+// generated by each benchmark to not slow down the tests.
+const ParallelBenchSize = 10_000_000
+
+var BenchWorkers = []int{1, 2, 4, 8, 16, 32}
+
+func BenchmarkParallelMaxK(b *testing.B) {
+	s := RandomFloats(ParallelBenchSize)
+	for _, workers := range BenchWorkers {
+		b.Run(fmt.Sprintf("max_100/%d/workers=%d", len(s), workers), func(b *testing.B) {
+			for range b.N {
+				ParallelMaxK(s, 100, workers)
+			}
+		})
+	}
+}
+
+func BenchmarkPairsParallelMaxK(b *testing.B) {
+	p := toPairs(RandomFloats(ParallelBenchSize))
+	for _, workers := range BenchWorkers {
+		b.Run(fmt.Sprintf("max_100/%d/workers=%d", len(p), workers), func(b *testing.B) {
+			for range b.N {
+				p.ParallelMaxK(100, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package slicex
+
+// This file contains the parallel variants of the set operations. The elements are hash-partitioned
+// between the workers, so that all the copies of an element are handled by the same worker with its
+// own map. Elements are hashed with the hash function argument, which must return the same hash for
+// equal elements, for example [HashString] or [HashInt].
+//
+// The results are the same as the ones of the sequential functions, with the same order.
+// The number of workers works as for the parallel selections, and it's at most 256.
+
+// maxSetWorkers is the maximum number of workers of the parallel set operations,
+// so that the worker of each element fits in a byte.
+const maxSetWorkers = 256
+
+// setWorkers returns the number of workers to use for set operations on n elements.
+func setWorkers(workers, n int) int {
+	return min(numWorkers(workers, n), maxSetWorkers)
+}
+
+// ParallelUnique is like [Unique], but the work is split between the workers.
+func ParallelUnique[E comparable](s []E, hash func(E) uint64, workers int) []E {
+	w := setWorkers(workers, len(s))
+	if w == 1 {
+		return Unique(s)
+	}
+	return parallelUnion([][]E{s}, hash, w)
+}
+
+// ParallelUnion is like [Union], but the work is split between the workers.
+func ParallelUnion[E comparable](hash func(E) uint64, workers int, inputs ...[]E) []E {
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	w := setWorkers(workers, size)
+	if w == 1 {
+		return Union(inputs...)
+	}
+	return parallelUnion(inputs, hash, w)
+}
+
+// parallelUnion keeps the first appearance of each element of the inputs.
+// Each worker keeps the elements it owns, and it visits them in the order of the inputs.
+func parallelUnion[E comparable](inputs [][]E, hash func(E) uint64, w int) []E {
+	owners := assignOwners(inputs, hash, w)
+	keep := make([][]bool, len(inputs))
+	for j, s := range inputs {
+		keep[j] = make([]bool, len(s))
+	}
+
+	parallel(w, w, func(o, _, _ int) {
+		seen := make(map[E]struct{})
+		for j, s := range inputs {
+			for i, e := range s {
+				if owners[j][i] != uint8(o) {
+					continue
+				}
+
+				if _, found := seen[e]; !found {
+					seen[e] = struct{}{}
+					keep[j][i] = true
+				}
+			}
+		}
+	})
+	return collectKept(inputs, keep, w)
+}
+
+// ParallelIntersection is like [Intersection], but the work is split between the workers.
+func ParallelIntersection[E comparable](hash func(E) uint64, workers int, inputs ...[]E) []E {
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	w := setWorkers(workers, size)
+	if w == 1 || len(inputs) < 2 {
+		return Intersection(inputs...)
+	}
+
+	// intersecting from the smallest set
+	i := smallest(inputs)
+	if len(inputs[i]) == 0 {
+		return []E{}
+	}
+
+	owners := assignOwners(inputs, hash, w)
+	keep := [][]bool{make([]bool, len(inputs[0]))}
+
+	parallel(w, w, func(o, _, _ int) {
+		interSet := make(map[E]struct{})
+		for k, e := range inputs[i] {
+			if owners[i][k] == uint8(o) {
+				interSet[e] = struct{}{}
+			}
+		}
+
+		current := make(map[E]struct{}, len(interSet))
+		for j, s := range inputs {
+			if j == i {
+				continue
+			}
+
+			if len(interSet) == 0 {
+				return
+			}
+
+			for k, e := range s {
+				if owners[j][k] != uint8(o) {
+					continue
+				}
+
+				if _, found := interSet[e]; found {
+					current[e] = struct{}{}
+				}
+			}
+
+			interSet, current = current, interSet
+			clear(current)
+		}
+
+		for k, e := range inputs[0] {
+			if owners[0][k] != uint8(o) {
+				continue
+			}
+
+			if _, found := interSet[e]; found {
+				keep[0][k] = true
+				delete(interSet, e) // remove duplicates
+			}
+		}
+	})
+	return collectKept(inputs[:1], keep, w)
+}
+
+// assignOwners returns the worker that owns each element of the inputs, out of w workers.
+func assignOwners[E any](inputs [][]E, hash func(E) uint64, w int) [][]uint8 {
+	owners := make([][]uint8, len(inputs))
+	for j, s := range inputs {
+		owners[j] = make([]uint8, len(s))
+		parallel(len(s), numWorkers(w, len(s)), func(_, lo, hi int) {
+			for i := lo; i < hi; i++ {
+				owners[j][i] = uint8(mix(hash(s[i])) % uint64(w))
+			}
+		})
+	}
+	return owners
+}
+
+// collectKept returns a new slice with the elements of the inputs that are marked in keep,
+// in the same order. It counts the kept elements of each shard to find where they go,
+// and then copies them in parallel.
+func collectKept[E any](inputs [][]E, keep [][]bool, w int) []E {
+	offsets := make([][]int, len(inputs))
+	var size int
+
+	for j, s := range inputs {
+		counts := make([]int, numWorkers(w, len(s)))
+		parallel(len(s), len(counts), func(shard, lo, hi int) {
+			var count int
+			for _, k := range keep[j][lo:hi] {
+				if k {
+					count++
+				}
+			}
+			counts[shard] = count
+		})
+
+		for shard, count := range counts {
+			counts[shard] = size
+			size += count
+		}
+		offsets[j] = counts
+	}
+
+	result := make([]E, size)
+	for j, s := range inputs {
+		parallel(len(s), len(offsets[j]), func(shard, lo, hi int) {
+			pos := offsets[j][shard]
+			for i := lo; i < hi; i++ {
+				if keep[j][i] {
+					result[pos] = s[i]
+					pos++
+				}
+			}
+		})
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package slicex
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestParallelSetOperations(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		inputs := [][]string{{"a", "b", "c", "b"}, {"c", "d", "a"}, {"a", "c", "e"}}
+		for _, workers := range []int{0, 1, 4} {
+			if u := ParallelUnique(inputs[0], HashString, workers); !reflect.DeepEqual(u, []string{"a", "b", "c"}) {
+				t.Errorf("workers=%d: ParallelUnique got %v", workers, u)
+			}
+
+			if u := ParallelUnion(HashString, workers, inputs...); !reflect.DeepEqual(u, []string{"a", "b", "c", "d", "e"}) {
+				t.Errorf("workers=%d: ParallelUnion got %v", workers, u)
+			}
+
+			if i := ParallelIntersection(HashString, workers, inputs...); !reflect.DeepEqual(i, []string{"a", "c"}) {
+				t.Errorf("workers=%d: ParallelIntersection got %v", workers, i)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 20 {
+			inputs := make([][]int, rand.IntN(4))
+			for i := range inputs {
+				inputs[i] = RandomInts(rand.IntN(4*minShardSize), 2*minShardSize)
+			}
+			workers := rand.IntN(10)
+
+			if len(inputs) > 0 {
+				if u, expected := ParallelUnique(inputs[0], HashInt, workers), Unique(inputs[0]); !reflect.DeepEqual(u, expected) {
+					t.Fatalf("workers=%d: ParallelUnique expected %v, got %v", workers, expected, u)
+				}
+			}
+
+			if u, expected := ParallelUnion(HashInt, workers, inputs...), Union(inputs...); !reflect.DeepEqual(u, expected) {
+				t.Fatalf("workers=%d: ParallelUnion expected %v, got %v", workers, expected, u)
+			}
+
+			if i, expected := ParallelIntersection(HashInt, workers, inputs...), Intersection(inputs...); !reflect.DeepEqual(i, expected) {
+				t.Fatalf("workers=%d: ParallelIntersection expected %v, got %v", workers, expected, i)
+			}
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		// run with -race to check that the workers don't race on the inputs or the results
+		inputs := make([][]string, 3)
+		for i := range inputs {
+			for _, n := range RandomInts(3*minShardSize, 4*minShardSize) {
+				inputs[i] = append(inputs[i], strconv.Itoa(n))
+			}
+		}
+
+		union := Union(inputs...)
+		inter := Intersection(inputs...)
+
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if u := ParallelUnion(HashString, 4, inputs...); !reflect.DeepEqual(u, union) {
+					t.Errorf("ParallelUnion expected %v, got %v", union, u)
+				}
+
+				if i := ParallelIntersection(HashString, 4, inputs...); !reflect.DeepEqual(i, inter) {
+					t.Errorf("ParallelIntersection expected %v, got %v", inter, i)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func BenchmarkParallelUnion(b *testing.B) {
+	bench := SetBenchs[len(SetBenchs)-1]
+	for _, workers := range BenchWorkers {
+		b.Run(fmt.Sprintf("size=%d/workers=%d", bench.size, workers), func(b *testing.B) {
+			for range b.N {
+				ParallelUnion(HashInt, workers, bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkParallelIntersection(b *testing.B) {
+	bench := SetBenchs[len(SetBenchs)-1]
+	for _, workers := range BenchWorkers {
+		b.Run(fmt.Sprintf("size=%d/workers=%d", bench.size, workers), func(b *testing.B) {
+			for range b.N {
+				ParallelIntersection(HashInt, workers, bench.s1, bench.s2)
+			}
+		})
+	}
+}
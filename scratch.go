@@ -0,0 +1,107 @@
+package slicex
+
+// Scratch holds the maps and output buffers used by the set operations, so that they can be
+// reused across calls. It's meant for hot loops, where the same operation is performed many
+// times: once the maps and buffers have grown to the size of the inputs, the methods of
+// Scratch don't allocate. With inputs of millions of elements, the maps may still allocate
+// for a few calls, because clearing them changes their hash seed and their internal tables settle again.
+//
+// The maps never shrink, and clearing them takes time proportional to the size they have grown to,
+// so after one big input every later call pays for it, even on small inputs. Use separate Scratch
+// values for inputs of very different sizes, or drop a Scratch to release its memory.
+//
+// The slices returned by the methods share memory with the Scratch, and are only valid until
+// the next method call. Copy them with [slices.Clone] to retain them for longer.
+// The zero value is ready to use. A Scratch must not be used concurrently.
+type Scratch[E comparable] struct {
+	set  map[E]struct{}
+	seen map[E]struct{}
+	out  [3][]E
+}
+
+// reset clears the maps and output buffers, allocating them on first use.
+func (ws *Scratch[E]) reset() {
+	if ws.set == nil {
+		ws.set = make(map[E]struct{})
+		ws.seen = make(map[E]struct{})
+	}
+
+	clear(ws.set)
+	clear(ws.seen)
+
+	for i := range ws.out {
+		if ws.out[i] == nil {
+			ws.out[i] = make([]E, 0, 8)
+		}
+		ws.out[i] = ws.out[i][:0]
+	}
+}
+
+// fill adds the elements of s to the set map.
+func (ws *Scratch[E]) fill(s []E) {
+	for _, e := range s {
+		ws.set[e] = struct{}{}
+	}
+}
+
+// Unique is like [Unique], but the result is only valid until the next call.
+func (ws *Scratch[E]) Unique(s []E) []E {
+	ws.reset()
+	ws.out[0] = uniqueInto(ws.out[0], ws.seen, s)
+	return ws.out[0]
+}
+
+// Intersection is like [Intersection], but the result is only valid until the next call.
+func (ws *Scratch[E]) Intersection(inputs ...[]E) []E {
+	ws.reset()
+	switch len(inputs) {
+	case 0:
+		return nil
+
+	case 1:
+		ws.out[0] = uniqueInto(ws.out[0], ws.seen, inputs[0])
+		return ws.out[0]
+
+	default:
+		i := smallest(inputs)
+		if len(inputs[i]) == 0 {
+			return ws.out[0]
+		}
+
+		ws.fill(inputs[i])
+		intersectSets(ws.set, ws.seen, i, inputs)
+		ws.out[0] = intersectionInto(ws.out[0], ws.set, inputs[0])
+		return ws.out[0]
+	}
+}
+
+// Union is like [Union], but the result is only valid until the next call.
+func (ws *Scratch[E]) Union(inputs ...[]E) []E {
+	ws.reset()
+	ws.out[0] = unionInto(ws.out[0], ws.seen, inputs)
+	return ws.out[0]
+}
+
+// Difference is like [Difference], but the result is only valid until the next call.
+func (ws *Scratch[E]) Difference(s1, s2 []E) []E {
+	ws.reset()
+	ws.fill(s2)
+	ws.out[0] = differenceInto(ws.out[0], ws.set, s1)
+	return ws.out[0]
+}
+
+// SymmetricDifference is like [SymmetricDifference], but the result is only valid until the next call.
+func (ws *Scratch[E]) SymmetricDifference(s1, s2 []E) []E {
+	ws.reset()
+	ws.fill(s2)
+	ws.out[0] = symmetricDifferenceInto(ws.out[0], ws.set, ws.seen, s1, s2)
+	return ws.out[0]
+}
+
+// Partition is like [Partition], but the results are only valid until the next call.
+func (ws *Scratch[E]) Partition(s1, s2 []E) (d12, inter, d21 []E) {
+	ws.reset()
+	ws.fill(s2)
+	ws.out[0], ws.out[1], ws.out[2] = partitionInto(ws.out[0], ws.out[1], ws.out[2], ws.set, ws.seen, s1, s2)
+	return ws.out[0], ws.out[1], ws.out[2]
+}
@@ -0,0 +1,114 @@
+package slicex
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestScratch(t *testing.T) {
+	var ws Scratch[int]
+
+	for range 100 {
+		s1 := RandomInts(rand.IntN(100), 50)
+		s2 := RandomInts(rand.IntN(100), 50)
+		s3 := RandomInts(rand.IntN(100), 50)
+
+		if unique, expected := ws.Unique(s1), Unique(s1); !slices.Equal(unique, expected) {
+			t.Fatalf("Unique: expected %v, got %v", expected, unique)
+		}
+
+		if inter, expected := ws.Intersection(s1, s2, s3), Intersection(s1, s2, s3); !slices.Equal(inter, expected) {
+			t.Fatalf("Intersection: expected %v, got %v", expected, inter)
+		}
+
+		if union, expected := ws.Union(s1, s2, s3), Union(s1, s2, s3); !slices.Equal(union, expected) {
+			t.Fatalf("Union: expected %v, got %v", expected, union)
+		}
+
+		if diff, expected := ws.Difference(s1, s2), Difference(s1, s2); !slices.Equal(diff, expected) {
+			t.Fatalf("Difference: expected %v, got %v", expected, diff)
+		}
+
+		if diff, expected := ws.SymmetricDifference(s1, s2), SymmetricDifference(s1, s2); !slices.Equal(diff, expected) {
+			t.Fatalf("SymmetricDifference: expected %v, got %v", expected, diff)
+		}
+
+		d12, inter, d21 := ws.Partition(s1, s2)
+		e12, eInter, e21 := Partition(s1, s2)
+		if !slices.Equal(d12, e12) || !slices.Equal(inter, eInter) || !slices.Equal(d21, e21) {
+			t.Fatalf("Partition: expected (%v,%v,%v), got (%v,%v,%v)", e12, eInter, e21, d12, inter, d21)
+		}
+	}
+}
+
+func TestScratchAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector adds allocations")
+	}
+
+	var ws Scratch[int]
+	s1 := RandomInts(1000, 500)
+	s2 := RandomInts(1000, 500)
+
+	ops := map[string]func(){
+		"Unique":              func() { ws.Unique(s1) },
+		"Intersection":        func() { ws.Intersection(s1, s2) },
+		"Union":               func() { ws.Union(s1, s2) },
+		"Difference":          func() { ws.Difference(s1, s2) },
+		"SymmetricDifference": func() { ws.SymmetricDifference(s1, s2) },
+		"Partition":           func() { ws.Partition(s1, s2) },
+	}
+
+	for name, op := range ops {
+		op() // warming up the maps and buffers
+		if allocs := testing.AllocsPerRun(100, op); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", name, allocs)
+		}
+	}
+}
+
+// -------------------------------- benchmarks --------------------------------
+
+func BenchmarkScratchPartition(b *testing.B) {
+	for _, bench := range SetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			var ws Scratch[int]
+			ws.Partition(bench.s1, bench.s2) // warming up the maps and buffers
+
+			b.ResetTimer()
+			for range b.N {
+				ws.Partition(bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkScratchUnion(b *testing.B) {
+	for _, bench := range SetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			var ws Scratch[int]
+			ws.Union(bench.s1, bench.s2, bench.s1, bench.s2) // warming up the maps and buffers
+
+			b.ResetTimer()
+			for range b.N {
+				ws.Union(bench.s1, bench.s2, bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkScratchDifference(b *testing.B) {
+	for _, bench := range SetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			var ws Scratch[int]
+			ws.Difference(bench.s1, bench.s2) // warming up the maps and buffers
+
+			b.ResetTimer()
+			for range b.N {
+				ws.Difference(bench.s1, bench.s2)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package slicex
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// UniqueSeq returns a sequence that yields the unique elements of seq,
+// preserving the order of their first appearance.
+// The input is consumed lazily, every time the returned sequence is iterated.
+func UniqueSeq[E comparable](seq iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seen := make(map[E]struct{})
+		for e := range seq {
+			if _, found := seen[e]; found {
+				continue
+			}
+
+			seen[e] = struct{}{}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// UnionSeq returns a sequence that yields the unique elements found in any of the sequences.
+// The order of elements reflects the one in the original sequences.
+// The inputs are consumed lazily, every time the returned sequence is iterated.
+func UnionSeq[E comparable](seqs ...iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seen := make(map[E]struct{})
+		for _, seq := range seqs {
+			for e := range seq {
+				if _, found := seen[e]; found {
+					continue
+				}
+
+				seen[e] = struct{}{}
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// IntersectionSeq returns a sequence that yields the unique elements found in all sequences.
+// The order of elements is the same as the one of the first sequence.
+//
+// Every time the returned sequence is iterated, all sequences but the first are
+// collected into sets, while the first one is consumed lazily.
+func IntersectionSeq[E comparable](seqs ...iter.Seq[E]) iter.Seq[E] {
+	switch len(seqs) {
+	case 0:
+		return func(yield func(E) bool) {}
+
+	case 1:
+		return UniqueSeq(seqs[0])
+
+	default:
+		return func(yield func(E) bool) {
+			interSet := collectSet(seqs[1])
+			for _, seq := range seqs[2:] {
+				if len(interSet) == 0 {
+					return
+				}
+
+				current := collectSet(seq)
+				for e := range interSet {
+					if _, found := current[e]; !found {
+						delete(interSet, e)
+					}
+				}
+			}
+
+			for e := range seqs[0] {
+				if len(interSet) == 0 {
+					return
+				}
+
+				if _, found := interSet[e]; found {
+					delete(interSet, e) // remove duplicates
+					if !yield(e) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// DifferenceSeq returns a sequence that yields the unique elements of seq1 not in seq2.
+// The order of elements is the same as seq1.
+//
+// Every time the returned sequence is iterated, seq2 is collected into a set,
+// while seq1 is consumed lazily.
+func DifferenceSeq[E comparable](seq1, seq2 iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		u2 := collectSet(seq2)
+		for e := range seq1 {
+			if _, found := u2[e]; found {
+				continue
+			}
+
+			u2[e] = struct{}{} // removing successive duplicates
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// MinKSeq returns the k smallest elements of the sequence, sorted in ascending order.
+// It uses memory proportional to k, regardless of the length of the sequence.
+func MinKSeq[E cmp.Ordered](seq iter.Seq[E], k int) []E {
+	if k < 1 {
+		return nil
+	}
+
+	mins := selectSeq(seq, k, minK[E])
+	slices.Sort(mins)
+	return mins
+}
+
+// MaxKSeq returns the k biggest elements of the sequence, sorted in descending order.
+// It uses memory proportional to k, regardless of the length of the sequence.
+func MaxKSeq[E cmp.Ordered](seq iter.Seq[E], k int) []E {
+	if k < 1 {
+		return nil
+	}
+
+	maxs := selectSeq(seq, k, maxK[E])
+	SortDescending(maxs)
+	return maxs
+}
+
+// selectSeq fills a window with the first k elements of the sequence, and then
+// feeds the following elements to the selection in batches of k.
+// It returns the window, which holds less than k elements if the sequence is shorter.
+// The window and the batch grow as elements arrive, so a big k doesn't allocate memory upfront.
+func selectSeq[E any](seq iter.Seq[E], k int, selection func(window, rest []E)) []E {
+	var window, batch []E
+	for e := range seq {
+		if window == nil {
+			window = make([]E, 0, min(k, initialTopKSize))
+		}
+
+		if len(window) < k {
+			window = append(window, e)
+			continue
+		}
+
+		if batch == nil {
+			batch = make([]E, 0, min(k, initialTopKSize))
+		}
+
+		batch = append(batch, e)
+		if len(batch) == k {
+			selection(window, batch)
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		selection(window, batch)
+	}
+	return window
+}
+
+func collectSet[E comparable](seq iter.Seq[E]) map[E]struct{} {
+	m := make(map[E]struct{})
+	for e := range seq {
+		m[e] = struct{}{}
+	}
+	return m
+}
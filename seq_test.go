@@ -0,0 +1,144 @@
+package slicex
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestUniqueSeq(t *testing.T) {
+	for range 100 {
+		s := RandomInts(rand.IntN(100), 50)
+
+		unique := slices.Collect(UniqueSeq(slices.Values(s)))
+		if expected := Unique(s); !slices.Equal(unique, expected) {
+			t.Fatalf("expected %v, got %v", expected, unique)
+		}
+	}
+}
+
+func TestUnionSeq(t *testing.T) {
+	for range 100 {
+		s1 := RandomInts(rand.IntN(100), 50)
+		s2 := RandomInts(rand.IntN(100), 50)
+		s3 := RandomInts(rand.IntN(100), 50)
+
+		union := slices.Collect(UnionSeq(slices.Values(s1), slices.Values(s2), slices.Values(s3)))
+		if expected := Union(s1, s2, s3); !slices.Equal(union, expected) {
+			t.Fatalf("expected %v, got %v", expected, union)
+		}
+	}
+}
+
+func TestIntersectionSeq(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		if inter := slices.Collect(IntersectionSeq[int]()); len(inter) != 0 {
+			t.Fatalf("expected empty intersection, got %v", inter)
+		}
+
+		inter := slices.Collect(IntersectionSeq(slices.Values([]int{3, 1, 3, 2})))
+		if expected := []int{3, 1, 2}; !slices.Equal(inter, expected) {
+			t.Fatalf("expected %v, got %v", expected, inter)
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			s1 := RandomInts(rand.IntN(100), 50)
+			s2 := RandomInts(rand.IntN(100), 50)
+			s3 := RandomInts(rand.IntN(100), 50)
+
+			inter := slices.Collect(IntersectionSeq(slices.Values(s1), slices.Values(s2), slices.Values(s3)))
+			if expected := Intersection(s1, s2, s3); !slices.Equal(inter, expected) {
+				t.Fatalf("expected %v, got %v", expected, inter)
+			}
+		}
+	})
+}
+
+func TestDifferenceSeq(t *testing.T) {
+	for range 100 {
+		s1 := RandomInts(rand.IntN(100), 50)
+		s2 := RandomInts(rand.IntN(100), 50)
+
+		diff := slices.Collect(DifferenceSeq(slices.Values(s1), slices.Values(s2)))
+		if expected := Difference(s1, s2); !slices.Equal(diff, expected) {
+			t.Fatalf("expected %v, got %v", expected, diff)
+		}
+	}
+}
+
+func TestSeqEarlyStop(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5}
+	seqs := map[string]iter.Seq[int]{
+		"unique":       UniqueSeq(slices.Values(s)),
+		"union":        UnionSeq(slices.Values(s), slices.Values(s)),
+		"intersection": IntersectionSeq(slices.Values(s), slices.Values(s)),
+		"difference":   DifferenceSeq(slices.Values(s), slices.Values([]int{})),
+	}
+
+	for name, seq := range seqs {
+		var got []int
+		for e := range seq {
+			got = append(got, e)
+			if len(got) == 2 {
+				break
+			}
+		}
+
+		if expected := []int{1, 2}; !slices.Equal(got, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, got)
+		}
+	}
+}
+
+func TestMinKSeq(t *testing.T) {
+	const iter = 1000
+	const size = 1000
+
+	for range iter {
+		k := rand.IntN(size)
+		s := RandomFloats(rand.IntN(size) + 1)
+
+		mins := MinKSeq(slices.Values(s), k)
+		expected := MinKNaive(s, k)
+
+		if !reflect.DeepEqual(mins, expected) {
+			t.Errorf("len(s) = %d; k = %d", len(s), k)
+			t.Fatalf("expected mins %v, got %v", expected, mins)
+		}
+	}
+
+	// the window grows with the sequence, so k can be bigger than any slice
+	mins := MinKSeq(slices.Values([]float64{2, 3, 1}), math.MaxInt)
+	if expected := []float64{1, 2, 3}; !slices.Equal(mins, expected) {
+		t.Fatalf("expected mins %v, got %v", expected, mins)
+	}
+}
+
+func TestMaxKSeq(t *testing.T) {
+	const iter = 1000
+	const size = 1000
+
+	for range iter {
+		k := rand.IntN(size)
+		s := RandomFloats(rand.IntN(size) + 1)
+
+		maxs := MaxKSeq(slices.Values(s), k)
+		expected := MaxKNaive(s, k)
+
+		if !reflect.DeepEqual(maxs, expected) {
+			t.Errorf("len(s) = %d; k = %d", len(s), k)
+			t.Fatalf("expected maxs %v, got %v", expected, maxs)
+		}
+	}
+
+	// the window grows with the sequence, so k can be bigger than any slice
+	maxs := MaxKSeq(slices.Values([]float64{2, 3, 1}), math.MaxInt)
+	if expected := []float64{3, 2, 1}; !slices.Equal(maxs, expected) {
+		t.Fatalf("expected maxs %v, got %v", expected, maxs)
+	}
+}
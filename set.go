@@ -1,5 +1,11 @@
 package slicex
 
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
 // Include adds a new element to the slice if it is not already present.
 // Returns the updated slice. Does not modify the original slice in place.
 func Include[E comparable](s []E, new E) []E {
@@ -11,6 +17,72 @@ func Include[E comparable](s []E, new E) []E {
 	return append(s, new)
 }
 
+const (
+	// includeMapSize is the number of new elements above which [IncludeAll]
+	// looks them up in a map instead of scanning the slice, unless both are small.
+	includeMapSize = 16
+
+	// includeScanSize is the total number of elements up to which [IncludeAll]
+	// scans the slice, because building the map costs more.
+	includeScanSize = 64
+)
+
+// IncludeAll adds the new elements that are not already present to the slice, in order and without duplicates.
+// Returns the updated slice, whose capacity is grown at most once. Does not modify the original slice in place.
+func IncludeAll[E comparable](s []E, news ...E) []E {
+	if len(news) == 0 {
+		return s
+	}
+
+	if len(news) <= includeMapSize || len(s)+len(news) <= includeScanSize {
+		// the i-th bit of missing is set if news[i] is to be appended
+		var missing uint64
+		count := 0
+
+		for i, e := range news {
+			if !slices.Contains(s, e) && !slices.Contains(news[:i], e) {
+				missing |= 1 << i
+				count++
+			}
+		}
+
+		s = slices.Grow(s, count)
+		for i, e := range news {
+			if missing&(1<<i) != 0 {
+				s = append(s, e)
+			}
+		}
+		return s
+	}
+
+	// pending[e] is true if e is to be appended
+	pending := make(map[E]bool, len(news))
+	for _, e := range news {
+		pending[e] = true
+	}
+
+	missing := len(pending)
+	for _, e := range s {
+		if missing == 0 {
+			break
+		}
+
+		if pending[e] {
+			pending[e] = false
+			missing--
+		}
+	}
+
+	s = slices.Grow(s, missing)
+	for _, e := range news {
+		if pending[e] {
+			pending[e] = false
+			s = append(s, e)
+		}
+	}
+	return s
+}
+
 // Exclude removes the first instance of an element from the slice if it exists.
 // The order of elements is **not preserved**. The removed slot is zeroed for GC safety.
 func Exclude[E comparable](s []E, del E) []E {
@@ -26,18 +98,131 @@ func Exclude[E comparable](s []E, del E) []E {
 	return s
 }
 
+// ExcludeStable removes the first instance of an element from the slice if it exists.
+// The order of elements is preserved, by shifting the following elements.
+// The vacated slot is zeroed for GC safety.
+func ExcludeStable[E comparable](s []E, del E) []E {
+	for i, e := range s {
+		if e == del {
+			var zero E
+			var last = len(s) - 1
+
+			copy(s[i:], s[i+1:])
+			s[last] = zero
+			return s[:last]
+		}
+	}
+	return s
+}
+
+// ExcludeAll removes all instances of an element from the slice, by moving the last elements into their slots.
+// The order of elements is **not preserved**. The vacated slots are zeroed for GC safety.
+func ExcludeAll[E comparable](s []E, del E) []E {
+	i, last := 0, len(s)
+	for i < last {
+		if s[i] == del {
+			last--
+			s[i] = s[last]
+			continue
+		}
+		i++
+	}
+
+	clear(s[last:])
+	return s[:last]
+}
+
+// ExcludeAllStable removes all instances of an element from the slice, in a single compaction pass.
+// The order of elements is preserved. The vacated slots are zeroed for GC safety.
+func ExcludeAllStable[E comparable](s []E, del E) []E {
+	i := 0
+	for _, e := range s {
+		if e != del {
+			s[i] = e
+			i++
+		}
+	}
+
+	clear(s[i:])
+	return s[:i]
+}
+
+// excludeMapSize is the number of elements to be removed above which [ExcludeMany]
+// looks them up in a map instead of scanning them.
+const excludeMapSize = 16
+
+// ExcludeMany removes all instances of each of the dels from the slice, in a single compaction pass.
+// The order of elements is preserved. The vacated slots are zeroed for GC safety.
+func ExcludeMany[E comparable](s []E, dels ...E) []E {
+	switch {
+	case len(dels) == 0:
+		return s
+
+	case len(dels) == 1:
+		return ExcludeAllStable(s, dels[0])
+
+	case len(dels) <= excludeMapSize:
+		i := 0
+		for _, e := range s {
+			if !slices.Contains(dels, e) {
+				s[i] = e
+				i++
+			}
+		}
+
+		clear(s[i:])
+		return s[:i]
+
+	default:
+		delSet := toSet(dels)
+		i := 0
+		for _, e := range s {
+			if _, found := delSet[e]; !found {
+				s[i] = e
+				i++
+			}
+		}
+
+		clear(s[i:])
+		return s[:i]
+	}
+}
+
 // Unique returns a new slice with no duplicates, preserving the order of elements.
 func Unique[E comparable](s []E) []E {
 	seen := make(map[E]struct{}, len(s))
 	unique := make([]E, 0, len(s))
+	return uniqueInto(unique, seen, s)
+}
+
+// uniqueInto appends to dst the unique elements of s that are not in seen, adding them to seen.
+func uniqueInto[E comparable](dst []E, seen map[E]struct{}, s []E) []E {
+	for _, e := range s {
+		if _, exists := seen[e]; !exists {
+			seen[e] = struct{}{}
+			dst = append(dst, e)
+		}
+	}
+	return dst
+}
+
+// UniqueInPlace removes the duplicates from the slice, preserving the order of elements.
+// It reuses the backing array of s, whose vacated tail is zeroed for GC safety, and
+// returns the shortened slice. Only the map for detecting duplicates is allocated.
+func UniqueInPlace[E comparable](s []E) []E {
+	seen := make(map[E]struct{}, len(s))
+	i := 0
 
 	for _, e := range s {
 		if _, exists := seen[e]; !exists {
 			seen[e] = struct{}{}
-			unique = append(unique, e)
+			s[i] = e
+			i++
 		}
 	}
-	return unique
+
+	clear(s[i:])
+	return s[:i]
 }
 
 // Intersection returns a new slice containing unique elements found in all slices.
@@ -51,49 +236,74 @@ func Intersection[E comparable](inputs ...[]E) []E {
 		return Unique(inputs[0])
 
 	default:
-		// intersecting from the smallest set
-		i, min := 0, len(inputs[0])
-		for j, s := range inputs {
-			if len(s) < min {
-				i = j
-				min = len(s)
-			}
-		}
-
-		if min == 0 {
+		i := smallest(inputs)
+		if len(inputs[i]) == 0 {
 			return []E{}
 		}
 
 		interSet := toSet(inputs[i])
-		for j, s := range inputs {
-			if j == i {
-				continue
-			}
+		current := make(map[E]struct{}, len(inputs[i]))
+		intersectSets(interSet, current, i, inputs)
 
-			current := toSet(s)
-			for e := range interSet {
-				if _, found := current[e]; !found {
-					delete(interSet, e)
-				}
-			}
+		inter := make([]E, 0, len(interSet))
+		return intersectionInto(inter, interSet, inputs[0])
+	}
+}
 
-			if len(interSet) == 0 {
-				return []E{}
-			}
+// smallest returns the position of the shortest of the inputs.
+func smallest[E any](inputs [][]E) int {
+	i, min := 0, len(inputs[0])
+	for j, s := range inputs {
+		if len(s) < min {
+			i = j
+			min = len(s)
 		}
+	}
+	return i
+}
 
-		inter := make([]E, 0, len(interSet))
-		for _, e := range inputs[0] {
-			if _, found := interSet[e]; found {
-				inter = append(inter, e)
-				delete(interSet, e) // remove duplicates
+// intersectSets removes from interSet the elements not found in all the inputs.
+// interSet must contain the elements of inputs[i], which is where the intersection starts from,
+// and current is a map used for holding the elements of the other inputs.
+func intersectSets[E comparable](interSet, current map[E]struct{}, i int, inputs [][]E) {
+	for j, s := range inputs {
+		if j == i {
+			continue
+		}
+
+		clear(current)
+		for _, e := range s {
+			current[e] = struct{}{}
+		}
+
+		for e := range interSet {
+			if _, found := current[e]; !found {
+				delete(interSet, e)
 			}
 		}
 
-		return inter
+		if len(interSet) == 0 {
+			return
+		}
 	}
 }
 
+// intersectionInto appends to dst the elements of first that are in interSet, in order,
+// removing them from interSet.
+func intersectionInto[E comparable](dst []E, interSet map[E]struct{}, first []E) []E {
+	for _, e := range first {
+		if len(interSet) == 0 {
+			break
+		}
+
+		if _, found := interSet[e]; found {
+			dst = append(dst, e)
+			delete(interSet, e) // remove duplicates
+		}
+	}
+	return dst
+}
+
 // Union returns a new slice containing unique elements found in any of the slices.
 // The order of elements reflects the one in the original slices.
 func Union[E comparable](inputs ...[]E) []E {
@@ -108,17 +318,21 @@ func Union[E comparable](inputs ...[]E) []E {
 
 	union := make([]E, 0, size)
 	seen := make(map[E]struct{}, size)
+	return unionInto(union, seen, inputs)
+}
 
+// unionInto appends to dst the unique elements found in any of the inputs that are not in seen,
+// adding them to seen.
+func unionInto[E comparable](dst []E, seen map[E]struct{}, inputs [][]E) []E {
 	for _, s := range inputs {
 		for _, e := range s {
 			if _, found := seen[e]; !found {
-				union = append(union, e)
+				dst = append(dst, e)
 				seen[e] = struct{}{}
 			}
 		}
 	}
-
-	return union
+	return dst
 }
 
 // Difference returns a new slice containing unique elements of s1 not in s2.
@@ -126,14 +340,18 @@ func Union[E comparable](inputs ...[]E) []E {
 func Difference[E comparable](s1, s2 []E) []E {
 	u2 := toSet(s2)
 	diff := make([]E, 0, len(s1))
+	return differenceInto(diff, u2, s1)
+}
 
+// differenceInto appends to dst the unique elements of s1 not in u2, adding them to u2.
+func differenceInto[E comparable](dst []E, u2 map[E]struct{}, s1 []E) []E {
 	for _, e := range s1 {
 		if _, found := u2[e]; !found {
-			diff = append(diff, e)
+			dst = append(dst, e)
 			u2[e] = struct{}{} // removing successive duplicates
 		}
 	}
-	return diff
+	return dst
 }
 
 // SymmetricDifference returns a new slice of unique elements present in either s1 or s2, but not both.
@@ -142,13 +360,18 @@ func SymmetricDifference[E comparable](s1, s2 []E) []E {
 	u2 := toSet(s2)
 	seen := make(map[E]struct{}, len(s1)+len(u2))
 	diff := make([]E, 0, len(s1)+len(u2))
+	return symmetricDifferenceInto(diff, u2, seen, s1, s2)
+}
 
+// symmetricDifferenceInto appends to dst the unique elements present in either s1 or s2, but not both.
+// u2 must contain the elements of s2, and seen must be empty.
+func symmetricDifferenceInto[E comparable](dst []E, u2, seen map[E]struct{}, s1, s2 []E) []E {
 	for _, e := range s1 {
 		if _, found := seen[e]; !found {
 			seen[e] = struct{}{} // removing duplicates
 
 			if _, in2 := u2[e]; !in2 {
-				diff = append(diff, e)
+				dst = append(dst, e)
 			}
 		}
 	}
@@ -158,11 +381,11 @@ func SymmetricDifference[E comparable](s1, s2 []E) []E {
 			// this element is unique to s2 since it was not found in the iteration over s1,
 			// so we mark it as seen and add it to the symmetric difference
 			seen[e] = struct{}{}
-			diff = append(diff, e)
+			dst = append(dst, e)
 		}
 	}
 
-	return diff
+	return dst
 }
 
 // Partition returns three unique and non-overlapping slices: elements only in s1, elements in both, and elements only in s2.
@@ -174,7 +397,12 @@ func Partition[E comparable](s1, s2 []E) (d12, inter, d21 []E) {
 	d12 = make([]E, 0, len(s1))
 	inter = make([]E, 0)
 	d21 = make([]E, 0, len(u2))
+	return partitionInto(d12, inter, d21, u2, seen, s1, s2)
+}
 
+// partitionInto appends to d12, inter and d21 the elements only in s1, in both, and only in s2 respectively.
+// u2 must contain the elements of s2, and seen must be empty.
+func partitionInto[E comparable](d12, inter, d21 []E, u2, seen map[E]struct{}, s1, s2 []E) ([]E, []E, []E) {
 	for _, e := range s1 {
 		if _, found := seen[e]; !found {
 			seen[e] = struct{}{} // removing duplicates
@@ -200,6 +428,292 @@ func Partition[E comparable](s1, s2 []E) (d12, inter, d21 []E) {
 	return d12, inter, d21
 }
 
+// IsSubset reports whether all the elements of a are in b.
+func IsSubset[E comparable](a, b []E) bool {
+	if len(a) == 0 {
+		return true
+	}
+
+	if len(b) == 0 {
+		return false
+	}
+
+	u := toSet(b)
+	for _, e := range a {
+		if _, found := u[e]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset reports whether all the elements of b are in a.
+func IsSuperset[E comparable](a, b []E) bool {
+	return IsSubset(b, a)
+}
+
+// IsDisjoint reports whether a and b have no elements in common.
+func IsDisjoint[E comparable](a, b []E) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+
+	// building the set from the smallest slice
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	u := toSet(a)
+	for _, e := range b {
+		if _, found := u[e]; found {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualSets reports whether a and b contain the same elements,
+// regardless of their order and of duplicates.
+func EqualSets[E comparable](a, b []E) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	ua := toSet(a)
+	ub := make(map[E]struct{}, len(ua))
+
+	for _, e := range b {
+		if _, found := ua[e]; !found {
+			return false
+		}
+		ub[e] = struct{}{}
+	}
+	return len(ua) == len(ub)
+}
+
+// Overlap returns the number of unique elements that a and b have in common,
+// which is the length of their [Intersection], without building it.
+func Overlap[E comparable](a, b []E) int {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	// building the set from the smallest slice
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+
+	u := toSet(a)
+	var overlap int
+
+	for _, e := range b {
+		if _, found := u[e]; found {
+			overlap++
+			delete(u, e) // remove duplicates
+
+			if len(u) == 0 {
+				break
+			}
+		}
+	}
+	return overlap
+}
+
+// Set is a collection of unique elements, backed by a map.
+//
+// Building a set once and passing it to functions like [IntersectionSet] or [DifferenceSet]
+// avoids rebuilding the same map at each call, which is what the slice-based functions do.
+// A nil Set can be read from but not added to. Use [FromSlice] or make to create one.
+type Set[E comparable] map[E]struct{}
+
+// FromSlice returns a new [Set] containing the elements of s.
+func FromSlice[E comparable](s []E) Set[E] {
+	return toSet(s)
+}
+
+// ToSlice returns a new slice containing the elements of the set, in no particular order.
+func (s Set[E]) ToSlice() []E {
+	slice := make([]E, 0, len(s))
+	for e := range s {
+		slice = append(slice, e)
+	}
+	return slice
+}
+
+// Len returns the number of elements in the set.
+func (s Set[E]) Len() int { return len(s) }
+
+// Has reports whether the element is in the set.
+func (s Set[E]) Has(e E) bool {
+	_, found := s[e]
+	return found
+}
+
+// Add adds the elements to the set.
+func (s Set[E]) Add(elems ...E) {
+	for _, e := range elems {
+		s[e] = struct{}{}
+	}
+}
+
+// Remove removes the elements from the set, if present.
+func (s Set[E]) Remove(elems ...E) {
+	for _, e := range elems {
+		delete(s, e)
+	}
+}
+
+// All returns an iterator over the elements of the set, in no particular order.
+func (s Set[E]) All() iter.Seq[E] {
+	return maps.Keys(s)
+}
+
+// Clone returns a copy of the set.
+func (s Set[E]) Clone() Set[E] {
+	clone := make(Set[E], len(s))
+	for e := range s {
+		clone[e] = struct{}{}
+	}
+	return clone
+}
+
+// UnionWith adds to s all the elements of other.
+func (s Set[E]) UnionWith(other Set[E]) {
+	for e := range other {
+		s[e] = struct{}{}
+	}
+}
+
+// IntersectWith removes from s all the elements not in other.
+func (s Set[E]) IntersectWith(other Set[E]) {
+	for e := range s {
+		if _, found := other[e]; !found {
+			delete(s, e)
+		}
+	}
+}
+
+// DifferenceWith removes from s all the elements in other.
+func (s Set[E]) DifferenceWith(other Set[E]) {
+	if len(other) < len(s) {
+		for e := range other {
+			delete(s, e)
+		}
+		return
+	}
+
+	for e := range s {
+		if _, found := other[e]; found {
+			delete(s, e)
+		}
+	}
+}
+
+// IntersectionSet returns a new slice containing the unique elements of s that are in the set.
+// The order of elements is the same as s. The set is not modified.
+//
+// It's equivalent to [Intersection] of s and the elements of the set,
+// but it doesn't rebuild the set at each call.
+func IntersectionSet[E comparable](s []E, set Set[E]) []E {
+	if len(s) == 0 || len(set) == 0 {
+		return []E{}
+	}
+
+	seen := make(map[E]struct{}, min(len(s), len(set)))
+	inter := make([]E, 0, min(len(s), len(set)))
+
+	for _, e := range s {
+		if _, found := set[e]; !found {
+			continue
+		}
+
+		if _, found := seen[e]; !found {
+			seen[e] = struct{}{} // removing duplicates
+			inter = append(inter, e)
+		}
+	}
+	return inter
+}
+
+// DifferenceSet returns a new slice containing the unique elements of s not in the set.
+// The order of elements is the same as s. The set is not modified.
+//
+// It's equivalent to [Difference] of s and the elements of the set,
+// but it doesn't rebuild the set at each call.
+func DifferenceSet[E comparable](s []E, set Set[E]) []E {
+	seen := make(map[E]struct{}, len(s))
+	diff := make([]E, 0, len(s))
+
+	for _, e := range s {
+		if _, found := set[e]; found {
+			continue
+		}
+
+		if _, found := seen[e]; !found {
+			seen[e] = struct{}{} // removing duplicates
+			diff = append(diff, e)
+		}
+	}
+	return diff
+}
+
+// SymmetricDifferenceSet returns a new slice of unique elements present in either s or the set, but not both.
+// The elements of s come first, in their order; the elements of the set follow in no particular order.
+// The set is not modified.
+func SymmetricDifferenceSet[E comparable](s []E, set Set[E]) []E {
+	seen := make(map[E]struct{}, len(s))
+	diff := make([]E, 0, len(s)+len(set))
+
+	for _, e := range s {
+		if _, found := seen[e]; !found {
+			seen[e] = struct{}{} // removing duplicates
+
+			if _, inSet := set[e]; !inSet {
+				diff = append(diff, e)
+			}
+		}
+	}
+
+	for e := range set {
+		if _, found := seen[e]; !found {
+			diff = append(diff, e)
+		}
+	}
+	return diff
+}
+
+// PartitionSet returns three unique and non-overlapping slices: elements only in s, elements in both,
+// and elements only in the set. The order of the first two is preserved from their first appearance in s,
+// while the elements only in the set are in no particular order. The set is not modified.
+func PartitionSet[E comparable](s []E, set Set[E]) (d12, inter, d21 []E) {
+	seen := make(map[E]struct{}, len(s))
+
+	d12 = make([]E, 0, len(s))
+	inter = make([]E, 0)
+	d21 = make([]E, 0, len(set))
+
+	for _, e := range s {
+		if _, found := seen[e]; !found {
+			seen[e] = struct{}{} // removing duplicates
+
+			_, inSet := set[e]
+			if inSet {
+				inter = append(inter, e)
+			} else {
+				d12 = append(d12, e)
+			}
+		}
+	}
+
+	for e := range set {
+		if _, found := seen[e]; !found {
+			d21 = append(d21, e)
+		}
+	}
+
+	return d12, inter, d21
+}
+
 func toSet[E comparable](s []E) map[E]struct{} {
 	m := make(map[E]struct{}, len(s))
 	for _, e := range s {
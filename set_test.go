@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand/v2"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -27,6 +28,59 @@ func TestInclude(t *testing.T) {
 	}
 }
 
+func TestIncludeAll(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			slice    []int
+			news     []int
+			expected []int
+		}{
+			{slice: nil, news: nil, expected: nil},
+			{slice: nil, news: []int{5, 5}, expected: []int{5}},
+			{slice: []int{1, 2, 0}, news: []int{5, 1, 6, 5}, expected: []int{1, 2, 0, 5, 6}},
+			{slice: []int{1, 2, 0}, news: []int{0, 1}, expected: []int{1, 2, 0}},
+		}
+
+		for i, test := range tests {
+			result := IncludeAll(test.slice, test.news...)
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("test %d: expected %v, got %v", i, test.expected, result)
+			}
+		}
+	})
+
+	t.Run("single growth", func(t *testing.T) {
+		if raceEnabled {
+			t.Skip("the race detector adds allocations")
+		}
+
+		s := []int{1, 2, 3}
+		news := []int{4, 5, 6, 7, 8, 9, 10, 11, 12}
+
+		allocs := testing.AllocsPerRun(100, func() { IncludeAll(s, news...) })
+		if allocs != 1 {
+			t.Fatalf("expected 1 allocation, got %v", allocs)
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			s := RandomInts(rand.IntN(100), 100)
+			news := RandomInts(rand.IntN(4*includeMapSize), 100)
+
+			expected := slices.Clone(s)
+			for _, e := range news {
+				expected = Include(expected, e)
+			}
+
+			result := IncludeAll(slices.Clip(s), news...)
+			if !slices.Equal(result, expected) {
+				t.Fatalf("expected %v, got %v", expected, result)
+			}
+		}
+	})
+}
+
 func TestExclude(t *testing.T) {
 	tests := []struct {
 		slice    []int
@@ -47,6 +101,133 @@ func TestExclude(t *testing.T) {
 	}
 }
 
+func TestExcludeStable(t *testing.T) {
+	tests := []struct {
+		slice    []int
+		element  int
+		expected []int
+	}{
+		{slice: nil, element: 5, expected: nil},
+		{slice: []int{}, element: 5, expected: []int{}},
+		{slice: []int{1, 2, 0}, element: 5, expected: []int{1, 2, 0}},
+		{slice: []int{1, 2, 0, 3, 0, 4}, element: 0, expected: []int{1, 2, 3, 0, 4}},
+	}
+
+	for i, test := range tests {
+		original := test.slice
+		result := ExcludeStable(test.slice, test.element)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, result)
+		}
+
+		if !isZero(original[len(result):]) {
+			t.Errorf("test %d: the vacated tail %v is not zeroed", i, original[len(result):])
+		}
+	}
+}
+
+func TestExcludeAll(t *testing.T) {
+	tests := []struct {
+		slice    []int
+		element  int
+		expected []int
+	}{
+		{slice: nil, element: 5, expected: nil},
+		{slice: []int{}, element: 5, expected: []int{}},
+		{slice: []int{1, 2, 3}, element: 5, expected: []int{1, 2, 3}},
+		{slice: []int{7, 1, 7, 2, 7, 3, 7}, element: 7, expected: []int{3, 1, 2}},
+		{slice: []int{1, 7, 2, 3}, element: 7, expected: []int{1, 3, 2}},
+		{slice: []int{7, 7}, element: 7, expected: []int{}},
+	}
+
+	for i, test := range tests {
+		original := test.slice
+		result := ExcludeAll(test.slice, test.element)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, result)
+		}
+
+		if !isZero(original[len(result):]) {
+			t.Errorf("test %d: the vacated tail %v is not zeroed", i, original[len(result):])
+		}
+	}
+}
+
+func TestExcludeAllStable(t *testing.T) {
+	tests := []struct {
+		slice    []int
+		element  int
+		expected []int
+	}{
+		{slice: nil, element: 5, expected: nil},
+		{slice: []int{}, element: 5, expected: []int{}},
+		{slice: []int{1, 2, 3}, element: 5, expected: []int{1, 2, 3}},
+		{slice: []int{7, 1, 7, 2, 7, 3, 7}, element: 7, expected: []int{1, 2, 3}},
+		{slice: []int{7, 7}, element: 7, expected: []int{}},
+	}
+
+	for i, test := range tests {
+		original := test.slice
+		result := ExcludeAllStable(test.slice, test.element)
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, result)
+		}
+
+		if !isZero(original[len(result):]) {
+			t.Errorf("test %d: the vacated tail %v is not zeroed", i, original[len(result):])
+		}
+	}
+}
+
+func TestExcludeMany(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			slice    []int
+			dels     []int
+			expected []int
+		}{
+			{slice: nil, dels: []int{1, 2}, expected: nil},
+			{slice: []int{1, 2, 3}, dels: nil, expected: []int{1, 2, 3}},
+			{slice: []int{1, 2, 3, 1}, dels: []int{1}, expected: []int{2, 3}},
+			{slice: []int{4, 1, 2, 3, 1, 5}, dels: []int{1, 3, 9}, expected: []int{4, 2, 5}},
+		}
+
+		for i, test := range tests {
+			original := test.slice
+			result := ExcludeMany(test.slice, test.dels...)
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Errorf("test %d: expected %v, got %v", i, test.expected, result)
+			}
+
+			if !isZero(original[len(result):]) {
+				t.Errorf("test %d: the vacated tail %v is not zeroed", i, original[len(result):])
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			s := RandomInts(rand.IntN(100), 50)
+			dels := RandomInts(rand.IntN(2*excludeMapSize), 50)
+
+			expected := slices.DeleteFunc(slices.Clone(s), func(e int) bool { return slices.Contains(dels, e) })
+			if result := ExcludeMany(s, dels...); !slices.Equal(result, expected) {
+				t.Fatalf("expected %v, got %v", expected, result)
+			}
+		}
+	})
+}
+
+func isZero[E comparable](s []E) bool {
+	var zero E
+	for _, e := range s {
+		if e != zero {
+			return false
+		}
+	}
+	return true
+}
+
 func TestUnique(t *testing.T) {
 	tests := []struct {
 		slice    []int
@@ -66,6 +247,30 @@ func TestUnique(t *testing.T) {
 	}
 }
 
+func TestUniqueInPlace(t *testing.T) {
+	tests := []struct {
+		slice    []int
+		expected []int
+	}{
+		{slice: nil, expected: nil},
+		{slice: []int{}, expected: []int{}},
+		{slice: []int{1, 2, 0}, expected: []int{1, 2, 0}},
+		{slice: []int{1, 2, 0, 3, 1, 0}, expected: []int{1, 2, 0, 3}},
+	}
+
+	for i, test := range tests {
+		original := test.slice
+		unique := UniqueInPlace(test.slice)
+		if !reflect.DeepEqual(unique, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, unique)
+		}
+
+		if !isZero(original[len(unique):]) {
+			t.Errorf("test %d: the vacated tail %v is not zeroed", i, original[len(unique):])
+		}
+	}
+}
+
 func TestUnion(t *testing.T) {
 	tests := []struct {
 		slices   [][]int
@@ -150,6 +355,149 @@ func TestPartition(t *testing.T) {
 	}
 }
 
+func TestSetRelations(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			a, b                              []int
+			subset, superset, disjoint, equal bool
+			overlap                           int
+		}{
+			{a: nil, b: nil, subset: true, superset: true, disjoint: true, equal: true},
+			{a: nil, b: []int{1}, subset: true, superset: false, disjoint: true, equal: false},
+			{a: []int{1, 1, 2}, b: []int{2, 1}, subset: true, superset: true, disjoint: false, equal: true, overlap: 2},
+			{a: []int{1, 2}, b: []int{2, 3, 1}, subset: true, superset: false, disjoint: false, equal: false, overlap: 2},
+			{a: []int{1, 4}, b: []int{2, 3}, subset: false, superset: false, disjoint: true, equal: false},
+		}
+
+		for i, test := range tests {
+			if subset := IsSubset(test.a, test.b); subset != test.subset {
+				t.Errorf("test %d: IsSubset expected %v, got %v", i, test.subset, subset)
+			}
+
+			if superset := IsSuperset(test.a, test.b); superset != test.superset {
+				t.Errorf("test %d: IsSuperset expected %v, got %v", i, test.superset, superset)
+			}
+
+			if disjoint := IsDisjoint(test.a, test.b); disjoint != test.disjoint {
+				t.Errorf("test %d: IsDisjoint expected %v, got %v", i, test.disjoint, disjoint)
+			}
+
+			if equal := EqualSets(test.a, test.b); equal != test.equal {
+				t.Errorf("test %d: EqualSets expected %v, got %v", i, test.equal, equal)
+			}
+
+			if overlap := Overlap(test.a, test.b); overlap != test.overlap {
+				t.Errorf("test %d: Overlap expected %v, got %v", i, test.overlap, overlap)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 1000 {
+			a := RandomInts(rand.IntN(10), 10)
+			b := RandomInts(rand.IntN(20), 10)
+
+			if subset, expected := IsSubset(a, b), len(Difference(a, b)) == 0; subset != expected {
+				t.Fatalf("IsSubset(%v, %v): expected %v, got %v", a, b, expected, subset)
+			}
+
+			if disjoint, expected := IsDisjoint(a, b), len(Intersection(a, b)) == 0; disjoint != expected {
+				t.Fatalf("IsDisjoint(%v, %v): expected %v, got %v", a, b, expected, disjoint)
+			}
+
+			if equal, expected := EqualSets(a, b), len(SymmetricDifference(a, b)) == 0; equal != expected {
+				t.Fatalf("EqualSets(%v, %v): expected %v, got %v", a, b, expected, equal)
+			}
+
+			if overlap, expected := Overlap(a, b), len(Intersection(a, b)); overlap != expected {
+				t.Fatalf("Overlap(%v, %v): expected %v, got %v", a, b, expected, overlap)
+			}
+		}
+	})
+}
+
+func TestSet(t *testing.T) {
+	set := FromSlice([]int{1, 2, 2, 3})
+	if set.Len() != 3 || !set.Has(1) || !set.Has(2) || !set.Has(3) || set.Has(4) {
+		t.Fatalf("FromSlice: unexpected set %v", set)
+	}
+
+	set.Add(4, 5)
+	set.Remove(1, 9)
+	if expected := FromSlice([]int{2, 3, 4, 5}); !reflect.DeepEqual(set, expected) {
+		t.Fatalf("Add/Remove: expected %v, got %v", expected, set)
+	}
+
+	clone := set.Clone()
+	clone.Add(99)
+	if set.Has(99) {
+		t.Fatalf("Clone: modifying the clone modified the original set")
+	}
+
+	union := set.Clone()
+	union.UnionWith(FromSlice([]int{5, 6}))
+	if expected := FromSlice([]int{2, 3, 4, 5, 6}); !reflect.DeepEqual(union, expected) {
+		t.Fatalf("UnionWith: expected %v, got %v", expected, union)
+	}
+
+	inter := set.Clone()
+	inter.IntersectWith(FromSlice([]int{5, 6, 2}))
+	if expected := FromSlice([]int{2, 5}); !reflect.DeepEqual(inter, expected) {
+		t.Fatalf("IntersectWith: expected %v, got %v", expected, inter)
+	}
+
+	diff := set.Clone()
+	diff.DifferenceWith(FromSlice([]int{5, 6, 2}))
+	if expected := FromSlice([]int{3, 4}); !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("DifferenceWith: expected %v, got %v", expected, diff)
+	}
+
+	elems := slices.Sorted(set.All())
+	if expected := []int{2, 3, 4, 5}; !reflect.DeepEqual(elems, expected) {
+		t.Fatalf("All: expected %v, got %v", expected, elems)
+	}
+
+	elems = set.ToSlice()
+	slices.Sort(elems)
+	if expected := []int{2, 3, 4, 5}; !reflect.DeepEqual(elems, expected) {
+		t.Fatalf("ToSlice: expected %v, got %v", expected, elems)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	for range 100 {
+		s1 := RandomInts(rand.IntN(100), 50)
+		s2 := RandomInts(rand.IntN(100), 50)
+
+		set := FromSlice(s2)
+		original := set.Clone()
+
+		if inter, expected := IntersectionSet(s1, set), Intersection(s1, s2); !reflect.DeepEqual(inter, expected) {
+			t.Fatalf("IntersectionSet: expected %v, got %v", expected, inter)
+		}
+
+		if diff, expected := DifferenceSet(s1, set), Difference(s1, s2); !reflect.DeepEqual(diff, expected) {
+			t.Fatalf("DifferenceSet: expected %v, got %v", expected, diff)
+		}
+
+		symDiff, expected := SymmetricDifferenceSet(s1, set), SymmetricDifference(s1, s2)
+		if !reflect.DeepEqual(FromSlice(symDiff), FromSlice(expected)) || len(symDiff) != len(expected) {
+			t.Fatalf("SymmetricDifferenceSet: expected %v, got %v", expected, symDiff)
+		}
+
+		d12, inter, d21 := PartitionSet(s1, set)
+		e12, eInter, e21 := Partition(s1, s2)
+		if !reflect.DeepEqual(d12, e12) || !reflect.DeepEqual(inter, eInter) ||
+			!reflect.DeepEqual(FromSlice(d21), FromSlice(e21)) || len(d21) != len(e21) {
+			t.Fatalf("PartitionSet: expected (%v,%v,%v), got (%v,%v,%v)", e12, eInter, e21, d12, inter, d21)
+		}
+
+		if !reflect.DeepEqual(set, original) {
+			t.Fatalf("the set was modified")
+		}
+	}
+}
+
 // ---------------------------------- benchmarks --------------------------------
 
 type bench struct {
@@ -213,6 +561,18 @@ func BenchmarkUnique(b *testing.B) {
 	}
 }
 
+func BenchmarkUniqueInPlace(b *testing.B) {
+	for _, bench := range SetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			s := make([]int, bench.size)
+			for range b.N {
+				copy(s, bench.s1)
+				UniqueInPlace(s)
+			}
+		})
+	}
+}
+
 func BenchmarkIntersection(b *testing.B) {
 	for _, bench := range SetBenchs {
 		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
@@ -262,3 +622,25 @@ func BenchmarkPartition(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkDifferenceSet(b *testing.B) {
+	for _, bench := range SetBenchs {
+		set := FromSlice(bench.s2)
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				DifferenceSet(bench.s1, set)
+			}
+		})
+	}
+}
+
+func BenchmarkIncludeAll(b *testing.B) {
+	for _, bench := range SetBenchs {
+		news := bench.s2[:min(len(bench.s2), 10_000)]
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				IncludeAll(bench.s1, news...)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package slicex
+
+// This file contains the variants of the set operations that compare elements by a key,
+// as returned by the key function, instead of comparing whole elements.
+// When several elements have the same key, the first one to appear is the one kept.
+
+// IncludeBy adds a new element to the slice if no element with the same key is already present.
+// Returns the updated slice. Does not modify the original slice in place.
+func IncludeBy[E any, K comparable](s []E, new E, key func(E) K) []E {
+	k := key(new)
+	for _, e := range s {
+		if key(e) == k {
+			return s
+		}
+	}
+	return append(s, new)
+}
+
+// ExcludeBy removes the first element with the same key as del from the slice, if it exists.
+// The order of elements is **not preserved**. The removed slot is zeroed for GC safety.
+func ExcludeBy[E any, K comparable](s []E, del E, key func(E) K) []E {
+	k := key(del)
+	for i, e := range s {
+		if key(e) == k {
+			var zero E
+			var last = len(s) - 1
+
+			s[i], s[last] = s[last], zero
+			return s[:last]
+		}
+	}
+	return s
+}
+
+// UniqueBy returns a new slice with no elements with duplicate keys, preserving the order of elements.
+func UniqueBy[E any, K comparable](s []E, key func(E) K) []E {
+	seen := make(map[K]struct{}, len(s))
+	unique := make([]E, 0, len(s))
+
+	for _, e := range s {
+		k := key(e)
+		if _, exists := seen[k]; !exists {
+			seen[k] = struct{}{}
+			unique = append(unique, e)
+		}
+	}
+	return unique
+}
+
+// IntersectionBy returns a new slice containing the elements of the first slice whose key is found in all slices,
+// with no duplicate keys. The order of elements is the same as the one of the first slice.
+func IntersectionBy[E any, K comparable](key func(E) K, inputs ...[]E) []E {
+	switch len(inputs) {
+	case 0:
+		return nil
+
+	case 1:
+		return UniqueBy(inputs[0], key)
+
+	default:
+		// intersecting from the smallest set
+		i := smallest(inputs)
+		if len(inputs[i]) == 0 {
+			return []E{}
+		}
+
+		interSet := toKeySet(inputs[i], key)
+		for j, s := range inputs {
+			if j == i {
+				continue
+			}
+
+			current := toKeySet(s, key)
+			for k := range interSet {
+				if _, found := current[k]; !found {
+					delete(interSet, k)
+				}
+			}
+
+			if len(interSet) == 0 {
+				return []E{}
+			}
+		}
+
+		inter := make([]E, 0, len(interSet))
+		for _, e := range inputs[0] {
+			k := key(e)
+			if _, found := interSet[k]; found {
+				inter = append(inter, e)
+				delete(interSet, k) // remove duplicates
+			}
+		}
+
+		return inter
+	}
+}
+
+// UnionBy returns a new slice containing the elements found in any of the slices, with no duplicate keys.
+// The order of elements reflects the one in the original slices.
+func UnionBy[E any, K comparable](key func(E) K, inputs ...[]E) []E {
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	if size == 0 {
+		return []E{}
+	}
+
+	union := make([]E, 0, size)
+	seen := make(map[K]struct{}, size)
+
+	for _, s := range inputs {
+		for _, e := range s {
+			k := key(e)
+			if _, found := seen[k]; !found {
+				union = append(union, e)
+				seen[k] = struct{}{}
+			}
+		}
+	}
+
+	return union
+}
+
+// DifferenceBy returns a new slice containing the elements of s1 whose key is not in s2, with no duplicate keys.
+// The order of elements is the same as s1.
+func DifferenceBy[E any, K comparable](s1, s2 []E, key func(E) K) []E {
+	u2 := toKeySet(s2, key)
+	diff := make([]E, 0, len(s1))
+
+	for _, e := range s1 {
+		k := key(e)
+		if _, found := u2[k]; !found {
+			diff = append(diff, e)
+			u2[k] = struct{}{} // removing successive duplicates
+		}
+	}
+	return diff
+}
+
+// SymmetricDifferenceBy returns a new slice of elements whose key is present in either s1 or s2, but not both,
+// with no duplicate keys. The order of elements is the same as the one of append(s1, s2...).
+func SymmetricDifferenceBy[E any, K comparable](s1, s2 []E, key func(E) K) []E {
+	u2 := toKeySet(s2, key)
+	seen := make(map[K]struct{}, len(s1)+len(u2))
+	diff := make([]E, 0, len(s1)+len(u2))
+
+	for _, e := range s1 {
+		k := key(e)
+		if _, found := seen[k]; !found {
+			seen[k] = struct{}{} // removing duplicates
+
+			if _, in2 := u2[k]; !in2 {
+				diff = append(diff, e)
+			}
+		}
+	}
+
+	for _, e := range s2 {
+		k := key(e)
+		if _, found := seen[k]; !found {
+			// this key is unique to s2 since it was not found in the iteration over s1,
+			// so we mark it as seen and add the element to the symmetric difference
+			seen[k] = struct{}{}
+			diff = append(diff, e)
+		}
+	}
+
+	return diff
+}
+
+// PartitionBy returns three non-overlapping slices with no duplicate keys: elements whose key is only in s1,
+// elements of s1 whose key is in both, and elements whose key is only in s2.
+// The order of elements is preserved from their first appearance in s1, then s2.
+func PartitionBy[E any, K comparable](s1, s2 []E, key func(E) K) (d12, inter, d21 []E) {
+	u2 := toKeySet(s2, key)
+	seen := make(map[K]struct{}, len(s1)+len(u2))
+
+	d12 = make([]E, 0, len(s1))
+	inter = make([]E, 0)
+	d21 = make([]E, 0, len(u2))
+
+	for _, e := range s1 {
+		k := key(e)
+		if _, found := seen[k]; !found {
+			seen[k] = struct{}{} // removing duplicates
+
+			_, in2 := u2[k]
+			if in2 {
+				inter = append(inter, e)
+			} else {
+				d12 = append(d12, e)
+			}
+		}
+	}
+
+	for _, e := range s2 {
+		k := key(e)
+		if _, found := seen[k]; !found {
+			// this key is unique to s2 since it was not found in the iteration over s1,
+			// so we mark it as seen and add the element to d21
+			seen[k] = struct{}{}
+			d21 = append(d21, e)
+		}
+	}
+
+	return d12, inter, d21
+}
+
+func toKeySet[E any, K comparable](s []E, key func(E) K) map[K]struct{} {
+	m := make(map[K]struct{}, len(s))
+	for _, e := range s {
+		m[key(e)] = struct{}{}
+	}
+	return m
+}
@@ -0,0 +1,97 @@
+package slicex
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+type user struct {
+	ID   int
+	Name string
+}
+
+func userID(u user) int { return u.ID }
+
+func TestIncludeExcludeBy(t *testing.T) {
+	users := []user{{1, "alice"}, {2, "bob"}}
+
+	if included := IncludeBy(users, user{1, "carol"}, userID); !reflect.DeepEqual(included, users) {
+		t.Fatalf("IncludeBy: expected %v, got %v", users, included)
+	}
+
+	expected := []user{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+	if included := IncludeBy(users, user{3, "carol"}, userID); !reflect.DeepEqual(included, expected) {
+		t.Fatalf("IncludeBy: expected %v, got %v", expected, included)
+	}
+
+	expected = []user{{2, "bob"}}
+	if excluded := ExcludeBy(users, user{1, ""}, userID); !reflect.DeepEqual(excluded, expected) {
+		t.Fatalf("ExcludeBy: expected %v, got %v", expected, excluded)
+	}
+}
+
+func TestSetOperationsBy(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		s1 := []user{{1, "alice"}, {2, "bob"}, {1, "alice2"}, {3, "carol"}}
+		s2 := []user{{3, "carol2"}, {4, "dave"}, {4, "dave2"}}
+
+		if unique, expected := UniqueBy(s1, userID), []user{{1, "alice"}, {2, "bob"}, {3, "carol"}}; !reflect.DeepEqual(unique, expected) {
+			t.Fatalf("UniqueBy: expected %v, got %v", expected, unique)
+		}
+
+		if union, expected := UnionBy(userID, s1, s2), []user{{1, "alice"}, {2, "bob"}, {3, "carol"}, {4, "dave"}}; !reflect.DeepEqual(union, expected) {
+			t.Fatalf("UnionBy: expected %v, got %v", expected, union)
+		}
+
+		if inter, expected := IntersectionBy(userID, s1, s2), []user{{3, "carol"}}; !reflect.DeepEqual(inter, expected) {
+			t.Fatalf("IntersectionBy: expected %v, got %v", expected, inter)
+		}
+
+		if diff, expected := DifferenceBy(s1, s2, userID), []user{{1, "alice"}, {2, "bob"}}; !reflect.DeepEqual(diff, expected) {
+			t.Fatalf("DifferenceBy: expected %v, got %v", expected, diff)
+		}
+
+		if diff, expected := SymmetricDifferenceBy(s1, s2, userID), []user{{1, "alice"}, {2, "bob"}, {4, "dave"}}; !reflect.DeepEqual(diff, expected) {
+			t.Fatalf("SymmetricDifferenceBy: expected %v, got %v", expected, diff)
+		}
+
+		d12, inter, d21 := PartitionBy(s1, s2, userID)
+		if !reflect.DeepEqual(d12, []user{{1, "alice"}, {2, "bob"}}) || !reflect.DeepEqual(inter, []user{{3, "carol"}}) || !reflect.DeepEqual(d21, []user{{4, "dave"}}) {
+			t.Fatalf("PartitionBy: got (%v,%v,%v)", d12, inter, d21)
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			s1 := RandomInts(rand.IntN(100), 50)
+			s2 := RandomInts(rand.IntN(100), 50)
+
+			if unique, expected := UniqueBy(s1, identity), Unique(s1); !reflect.DeepEqual(unique, expected) {
+				t.Fatalf("UniqueBy: expected %v, got %v", expected, unique)
+			}
+
+			if union, expected := UnionBy(identity, s1, s2), Union(s1, s2); !reflect.DeepEqual(union, expected) {
+				t.Fatalf("UnionBy: expected %v, got %v", expected, union)
+			}
+
+			if inter, expected := IntersectionBy(identity, s1, s2), Intersection(s1, s2); !reflect.DeepEqual(inter, expected) {
+				t.Fatalf("IntersectionBy: expected %v, got %v", expected, inter)
+			}
+
+			if diff, expected := DifferenceBy(s1, s2, identity), Difference(s1, s2); !reflect.DeepEqual(diff, expected) {
+				t.Fatalf("DifferenceBy: expected %v, got %v", expected, diff)
+			}
+
+			if diff, expected := SymmetricDifferenceBy(s1, s2, identity), SymmetricDifference(s1, s2); !reflect.DeepEqual(diff, expected) {
+				t.Fatalf("SymmetricDifferenceBy: expected %v, got %v", expected, diff)
+			}
+
+			d12, inter, d21 := PartitionBy(s1, s2, identity)
+			e12, eInter, e21 := Partition(s1, s2)
+			if !reflect.DeepEqual(d12, e12) || !reflect.DeepEqual(inter, eInter) || !reflect.DeepEqual(d21, e21) {
+				t.Fatalf("PartitionBy: expected (%v,%v,%v), got (%v,%v,%v)", e12, eInter, e21, d12, inter, d21)
+			}
+		}
+	})
+}
@@ -0,0 +1,244 @@
+package slicex
+
+import "cmp"
+
+// This file contains similarity metrics between slices, seen as sets of unique elements.
+// They only count elements, without building intersections and unions.
+// By convention, two empty slices are identical, so their similarity is 1.
+// The sorted variants compare elements like the sorted set operations, so NaNs are equal to each other,
+// while the others look them up in maps, where each NaN is a different key.
+
+// IntersectionSize returns the number of unique elements found in all slices,
+// which is the length of their [Intersection], without building it.
+func IntersectionSize[E comparable](inputs ...[]E) int {
+	switch len(inputs) {
+	case 0:
+		return 0
+
+	case 1:
+		return len(toSet(inputs[0]))
+
+	case 2:
+		return Overlap(inputs[0], inputs[1])
+
+	default:
+		i := smallest(inputs)
+		if len(inputs[i]) == 0 {
+			return 0
+		}
+
+		interSet := toSet(inputs[i])
+		current := make(map[E]struct{}, len(inputs[i]))
+		intersectSets(interSet, current, i, inputs)
+		return len(interSet)
+	}
+}
+
+// UnionSize returns the number of unique elements found in any of the slices,
+// which is the length of their [Union], without building it.
+func UnionSize[E comparable](inputs ...[]E) int {
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	seen := make(map[E]struct{}, size)
+	for _, s := range inputs {
+		for _, e := range s {
+			seen[e] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+// Jaccard returns the Jaccard index of a and b, which is the size of their
+// intersection divided by the size of their union.
+func Jaccard[E comparable](a, b []E) float64 {
+	return jaccard(setSizes(a, b))
+}
+
+// Dice returns the Sørensen–Dice coefficient of a and b, which is twice the size
+// of their intersection divided by the sum of their sizes.
+func Dice[E comparable](a, b []E) float64 {
+	return dice(setSizes(a, b))
+}
+
+// OverlapCoefficient returns the overlap coefficient (or Szymkiewicz–Simpson coefficient) of a and b,
+// which is the size of their intersection divided by the size of the smallest of the two.
+// It's 1 when one is a subset of the other, and 0 when only one of the two is empty.
+func OverlapCoefficient[E comparable](a, b []E) float64 {
+	return overlapCoefficient(setSizes(a, b))
+}
+
+// setSizes returns the number of unique elements of a, of b, and of their intersection.
+func setSizes[E comparable](a, b []E) (na, nb, inter int) {
+	ua := toSet(a)
+	ub := make(map[E]struct{}, min(len(b), len(ua)))
+
+	for _, e := range b {
+		if _, found := ub[e]; found {
+			continue
+		}
+
+		ub[e] = struct{}{}
+		if _, found := ua[e]; found {
+			inter++
+		}
+	}
+	return len(ua), len(ub), inter
+}
+
+// SortedIntersectionSize returns the number of unique elements found in all the sorted slices,
+// which is the length of their [SortedIntersection], without building it.
+func SortedIntersectionSize[E cmp.Ordered](inputs ...[]E) int {
+	if len(inputs) == 0 {
+		return 0
+	}
+
+	// looking up the elements of the smallest slice in the others
+	i := smallest(inputs)
+	pos := make([]int, len(inputs))
+	var size int
+
+next:
+	for k, e := range inputs[i] {
+		if k > 0 && !cmp.Less(inputs[i][k-1], e) {
+			continue
+		}
+
+		for j, s := range inputs {
+			if j == i {
+				continue
+			}
+
+			pos[j] = gallop(s, pos[j], e)
+			if pos[j] == len(s) {
+				break next
+			}
+
+			if cmp.Less(e, s[pos[j]]) {
+				continue next
+			}
+		}
+		size++
+	}
+	return size
+}
+
+// SortedUnionSize returns the number of unique elements found in any of the sorted slices,
+// which is the length of their [SortedUnion], without building it.
+func SortedUnionSize[E cmp.Ordered](inputs ...[]E) int {
+	pos := make([]int, len(inputs))
+	var size int
+
+	for {
+		// finding the smallest element not yet counted
+		var next E
+		found := false
+
+		for j, s := range inputs {
+			if pos[j] < len(s) && (!found || cmp.Less(s[pos[j]], next)) {
+				next = s[pos[j]]
+				found = true
+			}
+		}
+
+		if !found {
+			return size
+		}
+
+		size++
+		for j, s := range inputs {
+			for pos[j] < len(s) && !cmp.Less(next, s[pos[j]]) {
+				pos[j]++
+			}
+		}
+	}
+}
+
+// SortedJaccard is like [Jaccard], for sorted slices.
+func SortedJaccard[E cmp.Ordered](a, b []E) float64 {
+	return jaccard(sortedSetSizes(a, b))
+}
+
+// SortedDice is like [Dice], for sorted slices.
+func SortedDice[E cmp.Ordered](a, b []E) float64 {
+	return dice(sortedSetSizes(a, b))
+}
+
+// SortedOverlapCoefficient is like [OverlapCoefficient], for sorted slices.
+func SortedOverlapCoefficient[E cmp.Ordered](a, b []E) float64 {
+	return overlapCoefficient(sortedSetSizes(a, b))
+}
+
+// sortedSetSizes returns the number of unique elements of the sorted a, of the sorted b,
+// and of their intersection.
+func sortedSetSizes[E cmp.Ordered](a, b []E) (na, nb, inter int) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case cmp.Less(a[i], b[j]):
+			na++
+			i = skip(a, i)
+
+		case cmp.Less(b[j], a[i]):
+			nb++
+			j = skip(b, j)
+
+		default:
+			na++
+			nb++
+			inter++
+			i, j = skipEqual(a, b, i, j)
+		}
+	}
+
+	for i < len(a) {
+		na++
+		i = skip(a, i)
+	}
+
+	for j < len(b) {
+		nb++
+		j = skip(b, j)
+	}
+	return na, nb, inter
+}
+
+// skip returns the position of the first element of the sorted s after i that is different from s[i].
+func skip[E cmp.Ordered](s []E, i int) int {
+	e := s[i]
+	i++
+	for i < len(s) && !cmp.Less(e, s[i]) {
+		i++
+	}
+	return i
+}
+
+func jaccard(na, nb, inter int) float64 {
+	union := na + nb - inter
+	if union == 0 {
+		return 1
+	}
+	return float64(inter) / float64(union)
+}
+
+func dice(na, nb, inter int) float64 {
+	if na+nb == 0 {
+		return 1
+	}
+	return 2 * float64(inter) / float64(na+nb)
+}
+
+func overlapCoefficient(na, nb, inter int) float64 {
+	switch {
+	case na == 0 && nb == 0:
+		return 1
+
+	case na == 0 || nb == 0:
+		return 0
+
+	default:
+		return float64(inter) / float64(min(na, nb))
+	}
+}
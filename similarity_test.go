@@ -0,0 +1,217 @@
+package slicex
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSizes(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			inputs       [][]int
+			inter, union int
+		}{
+			{inputs: nil, inter: 0, union: 0},
+			{inputs: [][]int{{1, 1, 2}}, inter: 2, union: 2},
+			{inputs: [][]int{{1, 2, 3}, {}}, inter: 0, union: 3},
+			{inputs: [][]int{{1, 2, 3, 3}, {3, 2, 2}}, inter: 2, union: 3},
+			{inputs: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, inter: 1, union: 5},
+		}
+
+		for i, test := range tests {
+			if inter := IntersectionSize(test.inputs...); inter != test.inter {
+				t.Errorf("test %d: IntersectionSize expected %d, got %d", i, test.inter, inter)
+			}
+
+			if union := UnionSize(test.inputs...); union != test.union {
+				t.Errorf("test %d: UnionSize expected %d, got %d", i, test.union, union)
+			}
+
+			sorted := make([][]int, len(test.inputs))
+			for j, s := range test.inputs {
+				sorted[j] = slices.Sorted(slices.Values(s))
+			}
+
+			if inter := SortedIntersectionSize(sorted...); inter != test.inter {
+				t.Errorf("test %d: SortedIntersectionSize expected %d, got %d", i, test.inter, inter)
+			}
+
+			if union := SortedUnionSize(sorted...); union != test.union {
+				t.Errorf("test %d: SortedUnionSize expected %d, got %d", i, test.union, union)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		// NaNs are equal to each other and come first, as with slices.Sort
+		tests := []struct {
+			inputs       [][]float64
+			inter, union int
+		}{
+			{inputs: [][]float64{{nan}}, inter: 1, union: 1},
+			{inputs: [][]float64{{nan}, {}}, inter: 0, union: 1},
+			{inputs: [][]float64{{nan, nan, 1}, {nan, 2}}, inter: 1, union: 3},
+			{inputs: [][]float64{{nan, ninf, inf}, {ninf, 0, inf, inf}, {nan, ninf, inf}}, inter: 2, union: 4},
+		}
+
+		for i, test := range tests {
+			if inter := SortedIntersectionSize(test.inputs...); inter != test.inter {
+				t.Errorf("test %d: SortedIntersectionSize expected %d, got %d", i, test.inter, inter)
+			}
+
+			if union := SortedUnionSize(test.inputs...); union != test.union {
+				t.Errorf("test %d: SortedUnionSize expected %d, got %d", i, test.union, union)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			inputs := make([][]int, rand.IntN(5))
+			sorted := make([][]int, len(inputs))
+			for i := range inputs {
+				inputs[i] = RandomInts(rand.IntN(100), 50)
+				sorted[i] = slices.Sorted(slices.Values(inputs[i]))
+			}
+
+			inter := len(Intersection(inputs...))
+			union := len(Union(inputs...))
+
+			if size := IntersectionSize(inputs...); size != inter {
+				t.Fatalf("IntersectionSize expected %d, got %d", inter, size)
+			}
+
+			if size := UnionSize(inputs...); size != union {
+				t.Fatalf("UnionSize expected %d, got %d", union, size)
+			}
+
+			if size := SortedIntersectionSize(sorted...); size != inter {
+				t.Fatalf("SortedIntersectionSize expected %d, got %d", inter, size)
+			}
+
+			if size := SortedUnionSize(sorted...); size != union {
+				t.Fatalf("SortedUnionSize expected %d, got %d", union, size)
+			}
+		}
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			a, b                   []int
+			jaccard, dice, overlap float64
+		}{
+			{a: nil, b: nil, jaccard: 1, dice: 1, overlap: 1},
+			{a: []int{1, 2}, b: nil, jaccard: 0, dice: 0, overlap: 0},
+			{a: []int{1, 2}, b: []int{3, 4}, jaccard: 0, dice: 0, overlap: 0},
+			{a: []int{1, 2, 2}, b: []int{2, 1}, jaccard: 1, dice: 1, overlap: 1},
+			{a: []int{1, 2, 3, 4}, b: []int{3, 4, 5, 6}, jaccard: 1. / 3, dice: 0.5, overlap: 0.5},
+			{a: []int{1, 2, 3, 4}, b: []int{2, 4, 4}, jaccard: 0.5, dice: 2. / 3, overlap: 1},
+		}
+
+		for i, test := range tests {
+			slices.Sort(test.a)
+			slices.Sort(test.b)
+
+			if j := Jaccard(test.a, test.b); j != test.jaccard {
+				t.Errorf("test %d: Jaccard expected %v, got %v", i, test.jaccard, j)
+			}
+
+			if j := SortedJaccard(test.a, test.b); j != test.jaccard {
+				t.Errorf("test %d: SortedJaccard expected %v, got %v", i, test.jaccard, j)
+			}
+
+			if d := Dice(test.a, test.b); d != test.dice {
+				t.Errorf("test %d: Dice expected %v, got %v", i, test.dice, d)
+			}
+
+			if d := SortedDice(test.a, test.b); d != test.dice {
+				t.Errorf("test %d: SortedDice expected %v, got %v", i, test.dice, d)
+			}
+
+			if o := OverlapCoefficient(test.a, test.b); o != test.overlap {
+				t.Errorf("test %d: OverlapCoefficient expected %v, got %v", i, test.overlap, o)
+			}
+
+			if o := SortedOverlapCoefficient(test.a, test.b); o != test.overlap {
+				t.Errorf("test %d: SortedOverlapCoefficient expected %v, got %v", i, test.overlap, o)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		// NaNs are equal to each other and come first, as with slices.Sort
+		tests := []struct {
+			a, b                   []float64
+			jaccard, dice, overlap float64
+		}{
+			{a: []float64{nan}, b: nil, jaccard: 0, dice: 0, overlap: 0},
+			{a: []float64{nan, nan}, b: []float64{nan}, jaccard: 1, dice: 1, overlap: 1},
+			{a: []float64{nan, 1}, b: []float64{1, 2}, jaccard: 1. / 3, dice: 0.5, overlap: 0.5},
+			{a: []float64{nan, ninf, inf}, b: []float64{ninf, 0, inf}, jaccard: 0.5, dice: 2. / 3, overlap: 2. / 3},
+		}
+
+		for i, test := range tests {
+			if j := SortedJaccard(test.a, test.b); j != test.jaccard {
+				t.Errorf("test %d: SortedJaccard expected %v, got %v", i, test.jaccard, j)
+			}
+
+			if d := SortedDice(test.a, test.b); d != test.dice {
+				t.Errorf("test %d: SortedDice expected %v, got %v", i, test.dice, d)
+			}
+
+			if o := SortedOverlapCoefficient(test.a, test.b); o != test.overlap {
+				t.Errorf("test %d: SortedOverlapCoefficient expected %v, got %v", i, test.overlap, o)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			a := RandomInts(rand.IntN(100), 50)
+			b := RandomInts(rand.IntN(100), 50)
+
+			expected := 1.0
+			if union := len(Union(a, b)); union > 0 {
+				expected = float64(len(Intersection(a, b))) / float64(union)
+			}
+
+			if j := Jaccard(a, b); j != expected {
+				t.Fatalf("Jaccard expected %v, got %v", expected, j)
+			}
+
+			slices.Sort(a)
+			slices.Sort(b)
+			if j := SortedJaccard(a, b); j != expected {
+				t.Fatalf("SortedJaccard expected %v, got %v", expected, j)
+			}
+
+			if d, o := SortedDice(a, b), SortedOverlapCoefficient(a, b); d != Dice(a, b) || o != OverlapCoefficient(a, b) {
+				t.Fatalf("expected (%v,%v), got (%v,%v)", Dice(a, b), OverlapCoefficient(a, b), d, o)
+			}
+		}
+	})
+}
+
+func BenchmarkJaccard(b *testing.B) {
+	for _, bench := range SetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				Jaccard(bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkSortedJaccard(b *testing.B) {
+	for _, bench := range SortedSetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				SortedJaccard(bench.s1, bench.s2)
+			}
+		})
+	}
+}
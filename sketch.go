@@ -0,0 +1,324 @@
+package slicex
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// This file contains sketches, which are small fixed-size summaries of slices that estimate
+// their similarity and number of unique elements, without keeping the elements in memory.
+//
+// Sketches hash elements with the hash function they are created with, which must be
+// deterministic for sketches built in different processes to be comparable: for example
+// [HashString] or [HashInt]. Sketches can only be compared and merged with sketches
+// created with the same hash function and size.
+
+const (
+	// sketchVersion is the first byte of the binary encoding of sketches.
+	sketchVersion = 1
+
+	// MinPrecision and MaxPrecision are the bounds of the precision of a [CardinalityEstimator].
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+// HashString returns the 64-bit FNV-1a hash of s.
+func HashString(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// HashInt returns i as a 64-bit hash. Sketches mix hashes before using them,
+// so there is no need for integers to be scrambled.
+func HashInt[I ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](i I) uint64 {
+	return uint64(i)
+}
+
+// mix scrambles the bits of h with the finalizer of splitmix64, so that weak hashes
+// (like the identity on integers) are spread uniformly.
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+// MinHash is a signature of the unique elements added to it, which estimates the [Jaccard]
+// index of two slices from their signatures. It keeps, for each of its k hash functions,
+// the minimum hash of the elements: the fraction of these minimums two signatures have in common
+// estimates their Jaccard index, with a standard error of about 1/sqrt(k).
+//
+// The zero value is not usable, use [NewMinHash] or [MinHashFromSlice].
+type MinHash[E any] struct {
+	hash func(E) uint64
+	mins []uint64
+}
+
+// NewMinHash returns an empty [MinHash] with k hash functions, derived from the hash function.
+// It panics if k is smaller than 1.
+func NewMinHash[E any](k int, hash func(E) uint64) *MinHash[E] {
+	if k < 1 {
+		panic("slicex.NewMinHash: k must be at least 1")
+	}
+
+	m := &MinHash[E]{hash: hash, mins: make([]uint64, k)}
+	m.Reset()
+	return m
+}
+
+// MinHashFromSlice returns the [MinHash] signature of s with k hash functions, derived from the hash function.
+func MinHashFromSlice[E any](s []E, k int, hash func(E) uint64) *MinHash[E] {
+	m := NewMinHash(k, hash)
+	m.Add(s...)
+	return m
+}
+
+// Len returns the number of hash functions of the signature.
+func (m *MinHash[E]) Len() int { return len(m.mins) }
+
+// Reset empties the signature, as if no element was ever added.
+func (m *MinHash[E]) Reset() {
+	for i := range m.mins {
+		m.mins[i] = math.MaxUint64
+	}
+}
+
+// Add adds the elements to the signature.
+func (m *MinHash[E]) Add(elems ...E) {
+	for _, e := range elems {
+		h := mix(m.hash(e))
+		for i, current := range m.mins {
+			// the i-th hash function, which reuses the same hash with a different offset
+			if hi := mix(h + uint64(i)*0x9e3779b97f4a7c15); hi < current {
+				m.mins[i] = hi
+			}
+		}
+	}
+}
+
+// Merge adds to m the elements of the others, so that m becomes the signature of their union.
+// It panics if the signatures have a different number of hash functions.
+func (m *MinHash[E]) Merge(others ...*MinHash[E]) {
+	for _, o := range others {
+		if len(o.mins) != len(m.mins) {
+			panic("slicex.MinHash.Merge: signatures of different sizes")
+		}
+
+		for i, h := range o.mins {
+			if h < m.mins[i] {
+				m.mins[i] = h
+			}
+		}
+	}
+}
+
+// Jaccard returns the estimated [Jaccard] index of the elements of m and other.
+// As for [Jaccard], two empty signatures have a similarity of 1.
+// It panics if the signatures have a different number of hash functions.
+func (m *MinHash[E]) Jaccard(other *MinHash[E]) float64 {
+	if len(other.mins) != len(m.mins) {
+		panic("slicex.MinHash.Jaccard: signatures of different sizes")
+	}
+
+	var equal int
+	for i, h := range m.mins {
+		if h == other.mins[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(m.mins))
+}
+
+// Clone returns a copy of the signature, using the same hash function.
+func (m *MinHash[E]) Clone() *MinHash[E] {
+	mins := make([]uint64, len(m.mins))
+	copy(mins, m.mins)
+	return &MinHash[E]{hash: m.hash, mins: mins}
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. The hash function is not encoded.
+func (m *MinHash[E]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 1+binary.MaxVarintLen64+8*len(m.mins))
+	data = append(data, sketchVersion)
+	data = binary.AppendUvarint(data, uint64(len(m.mins)))
+	for _, h := range m.mins {
+		data = binary.LittleEndian.AppendUint64(data, h)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler]. The signature keeps its hash function,
+// so it should be created with [NewMinHash] and the same hash function of the encoded one.
+// The number of hash functions is the encoded one.
+func (m *MinHash[E]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != sketchVersion {
+		return errors.New("slicex.MinHash: unsupported encoding")
+	}
+
+	// comparing k with the number of minimums in the data, so that 8*k can't overflow
+	k, n := binary.Uvarint(data[1:])
+	if n <= 0 || k < 1 || (len(data)-1-n)%8 != 0 || k != uint64(len(data)-1-n)/8 {
+		return fmt.Errorf("slicex.MinHash: invalid encoding of %d bytes", len(data))
+	}
+
+	data = data[1+n:]
+	m.mins = make([]uint64, k)
+	for i := range m.mins {
+		m.mins[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	return nil
+}
+
+// CardinalityEstimator is a HyperLogLog sketch, which estimates the number of unique elements
+// added to it. With precision p it uses 2^p bytes, and it has a standard error of about 1.04/sqrt(2^p),
+// for example 0.8% with p = 14 (16 KiB).
+//
+// The zero value is not usable, use [NewCardinalityEstimator] or [CardinalityEstimatorFromSlice].
+type CardinalityEstimator[E any] struct {
+	hash      func(E) uint64
+	precision uint8
+	registers []uint8
+}
+
+// NewCardinalityEstimator returns an empty [CardinalityEstimator] with the given precision.
+// It panics if the precision is not between [MinPrecision] and [MaxPrecision].
+func NewCardinalityEstimator[E any](precision int, hash func(E) uint64) *CardinalityEstimator[E] {
+	if precision < MinPrecision || precision > MaxPrecision {
+		panic("slicex.NewCardinalityEstimator: precision out of range")
+	}
+
+	return &CardinalityEstimator[E]{
+		hash:      hash,
+		precision: uint8(precision),
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// CardinalityEstimatorFromSlice returns a [CardinalityEstimator] with the given precision,
+// to which the elements of s are added.
+func CardinalityEstimatorFromSlice[E any](s []E, precision int, hash func(E) uint64) *CardinalityEstimator[E] {
+	c := NewCardinalityEstimator(precision, hash)
+	c.Add(s...)
+	return c
+}
+
+// Precision returns the precision of the estimator.
+func (c *CardinalityEstimator[E]) Precision() int { return int(c.precision) }
+
+// Reset empties the estimator, as if no element was ever added.
+func (c *CardinalityEstimator[E]) Reset() { clear(c.registers) }
+
+// Add adds the elements to the estimator.
+func (c *CardinalityEstimator[E]) Add(elems ...E) {
+	p := c.precision
+	for _, e := range elems {
+		h := mix(c.hash(e))
+
+		// the first p bits select the register, the position of the first set bit
+		// in the others is the rank. The guard bit bounds the rank to 64-p+1.
+		i := h >> (64 - p)
+		rank := uint8(bits.LeadingZeros64(h<<p|1<<(p-1))) + 1
+		if rank > c.registers[i] {
+			c.registers[i] = rank
+		}
+	}
+}
+
+// Merge adds to c the elements of the others, so that c estimates the size of their union.
+// It panics if the estimators have a different precision.
+func (c *CardinalityEstimator[E]) Merge(others ...*CardinalityEstimator[E]) {
+	for _, o := range others {
+		if o.precision != c.precision {
+			panic("slicex.CardinalityEstimator.Merge: estimators of different precisions")
+		}
+
+		for i, r := range o.registers {
+			if r > c.registers[i] {
+				c.registers[i] = r
+			}
+		}
+	}
+}
+
+// Estimate returns the estimated number of unique elements added to the estimator.
+func (c *CardinalityEstimator[E]) Estimate() int {
+	m := float64(len(c.registers))
+	var sum float64
+	var zeros int
+
+	for _, r := range c.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(len(c.registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+// alpha returns the bias correction constant of HyperLogLog for m registers.
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
+
+// Clone returns a copy of the estimator, using the same hash function.
+func (c *CardinalityEstimator[E]) Clone() *CardinalityEstimator[E] {
+	registers := make([]uint8, len(c.registers))
+	copy(registers, c.registers)
+	return &CardinalityEstimator[E]{hash: c.hash, precision: c.precision, registers: registers}
+}
+
+// MarshalBinary implements [encoding.BinaryMarshaler]. The hash function is not encoded.
+func (c *CardinalityEstimator[E]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+len(c.registers))
+	data = append(data, sketchVersion, c.precision)
+	return append(data, c.registers...), nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler]. The estimator keeps its hash function,
+// so it should be created with [NewCardinalityEstimator] and the same hash function of the encoded one.
+// The precision is the encoded one.
+func (c *CardinalityEstimator[E]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != sketchVersion {
+		return errors.New("slicex.CardinalityEstimator: unsupported encoding")
+	}
+
+	p := int(data[1])
+	if p < MinPrecision || p > MaxPrecision || len(data)-2 != 1<<p {
+		return fmt.Errorf("slicex.CardinalityEstimator: invalid encoding of %d bytes", len(data))
+	}
+
+	for _, r := range data[2:] {
+		if r > uint8(64-p+1) {
+			return fmt.Errorf("slicex.CardinalityEstimator: invalid register value %d", r)
+		}
+	}
+
+	c.precision = uint8(p)
+	c.registers = make([]uint8, 1<<p)
+	copy(c.registers, data[2:])
+	return nil
+}
@@ -0,0 +1,165 @@
+package slicex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMinHash(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			a, b     []int
+			expected float64
+		}{
+			{a: nil, b: nil, expected: 1},
+			{a: []int{1, 2, 3}, b: nil, expected: 0},
+			{a: []int{1, 2, 3}, b: []int{3, 2, 1, 1}, expected: 1},
+			{a: []int{1, 2, 3}, b: []int{4, 5, 6}, expected: 0},
+		}
+
+		for i, test := range tests {
+			a := MinHashFromSlice(test.a, 64, HashInt)
+			b := MinHashFromSlice(test.b, 64, HashInt)
+
+			// disjoint sets can share a minimum by chance, but hardly more than one
+			if j := a.Jaccard(b); math.Abs(j-test.expected) > 1./64 {
+				t.Errorf("test %d: expected %v, got %v", i, test.expected, j)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			a := RandomInts(rand.IntN(1000), 1000)
+			b := RandomInts(rand.IntN(1000), 1000)
+
+			expected := Jaccard(a, b)
+			estimate := MinHashFromSlice(a, 256, HashInt).Jaccard(MinHashFromSlice(b, 256, HashInt))
+			if math.Abs(estimate-expected) > 0.2 {
+				t.Fatalf("expected %v, got %v", expected, estimate)
+			}
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		for range 100 {
+			a := RandomInts(rand.IntN(100), 100)
+			b := RandomInts(rand.IntN(100), 100)
+
+			merged := MinHashFromSlice(a, 32, HashInt)
+			merged.Merge(MinHashFromSlice(b, 32, HashInt))
+
+			expected := MinHashFromSlice(Union(a, b), 32, HashInt)
+			if !slices.Equal(merged.mins, expected.mins) {
+				t.Fatalf("expected %v, got %v", expected.mins, merged.mins)
+			}
+		}
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		m := MinHashFromSlice([]string{"a", "b", "c"}, 16, HashString)
+		data, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		decoded := NewMinHash(1, HashString)
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if decoded.Len() != 16 || decoded.Jaccard(m) != 1 {
+			t.Fatalf("expected %v, got %v", m.mins, decoded.mins)
+		}
+
+		// k = 2^61 makes 8*k overflow to 0, which matches the empty payload
+		overflow := binary.AppendUvarint([]byte{sketchVersion}, 1<<61)
+
+		for _, invalid := range [][]byte{nil, {0}, {sketchVersion}, {sketchVersion, 2, 0}, data[:len(data)-1], overflow} {
+			if err := decoded.UnmarshalBinary(invalid); err == nil {
+				t.Errorf("expected error for %v", invalid)
+			}
+		}
+	})
+}
+
+func TestCardinalityEstimator(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		for _, size := range []int{0, 1, 10, 100, 1000, 100_000} {
+			s := make([]int, 0, 2*size)
+			for i := range size {
+				s = append(s, i, i) // duplicates should not count
+			}
+
+			estimate := CardinalityEstimatorFromSlice(s, 14, HashInt).Estimate()
+			if math.Abs(float64(estimate-size)) > max(1, 0.05*float64(size)) {
+				t.Errorf("size %d: got %d", size, estimate)
+			}
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		for range 100 {
+			a := RandomInts(rand.IntN(1000), 10_000)
+			b := RandomInts(rand.IntN(1000), 10_000)
+
+			merged := CardinalityEstimatorFromSlice(a, 10, HashInt)
+			merged.Merge(CardinalityEstimatorFromSlice(b, 10, HashInt))
+
+			expected := CardinalityEstimatorFromSlice(Union(a, b), 10, HashInt)
+			if !slices.Equal(merged.registers, expected.registers) {
+				t.Fatalf("expected %v, got %v", expected.registers, merged.registers)
+			}
+		}
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		c := CardinalityEstimatorFromSlice([]string{"a", "b", "c"}, MinPrecision, HashString)
+		data, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		decoded := NewCardinalityEstimator(MaxPrecision, HashString)
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if decoded.Precision() != MinPrecision || !slices.Equal(decoded.registers, c.registers) {
+			t.Fatalf("expected %v, got %v", c.registers, decoded.registers)
+		}
+
+		invalidRegister := slices.Clone(data)
+		invalidRegister[2] = 64
+
+		for _, invalid := range [][]byte{nil, {0, 4}, {sketchVersion, 3}, data[:len(data)-1], invalidRegister} {
+			if err := decoded.UnmarshalBinary(invalid); err == nil {
+				t.Errorf("expected error for %v", invalid)
+			}
+		}
+	})
+}
+
+func BenchmarkMinHash(b *testing.B) {
+	for _, bench := range SetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				MinHashFromSlice(bench.s1, 128, HashInt)
+			}
+		})
+	}
+}
+
+func BenchmarkCardinalityEstimator(b *testing.B) {
+	for _, bench := range SetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				CardinalityEstimatorFromSlice(bench.s1, 14, HashInt).Estimate()
+			}
+		})
+	}
+}
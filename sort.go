@@ -2,7 +2,7 @@ package slicex
 
 import (
 	"cmp"
-
+	"iter"
 	"slices"
 )
 
@@ -19,7 +19,9 @@ func SortDescending[E cmp.Ordered](s []E) {
 
 // MinK returns the k smallest elements in the slice, sorted in ascending order.
 //
-// The original slice will be modified.
+// The original slice will be modified: the result is s[:min(k, len(s))], so it
+// aliases the backing array of s, and the order of the elements of s is not preserved.
+// Use [MinKCopy] to leave s untouched, and [MinKNaN] if s can contain NaNs.
 func MinK[E cmp.Ordered](s []E, k int) []E {
 	if k < 1 || len(s) == 0 {
 		return nil
@@ -31,52 +33,154 @@ func MinK[E cmp.Ordered](s []E, k int) []E {
 	}
 
 	mins := s[:k]
-	i, max := Max(mins)
-
-	for _, e := range s[k:] {
-		if e < max {
-			// swap out the biggest element with the new one
-			mins[i] = e
-			i, max = Max(mins)
-		}
-	}
-
+	minK(mins, s[k:])
 	slices.Sort(mins)
 	return mins
 }
 
 // MaxK returns the k biggest elements in the slice, sorted in descending order.
 //
-// The original slice will be modified.
+// The original slice will be modified: the result is s[:min(k, len(s))], so it
+// aliases the backing array of s, and the order of the elements of s is not preserved.
+// Use [MaxKCopy] to leave s untouched, and [MaxKNaN] if s can contain NaNs.
 func MaxK[E cmp.Ordered](s []E, k int) []E {
 	if k < 1 || len(s) == 0 {
 		return nil
 	}
 
 	if k >= len(s) {
-		slices.Sort(s)
-		slices.Reverse(s)
+		SortDescending(s)
 		return s
 	}
 
 	maxs := s[:k]
+	maxK(maxs, s[k:])
+	SortDescending(maxs)
+	return maxs
+}
+
+// MinKCopy returns a new slice with the k smallest elements in s, sorted in ascending order.
+//
+// Unlike [MinK], the original slice is never written to, and the result
+// does not share memory with it. Only min(k, len(s)) elements are allocated.
+func MinKCopy[E cmp.Ordered](s []E, k int) []E {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	k = min(k, len(s))
+	mins := make([]E, k)
+	copy(mins, s[:k])
+
+	minK(mins, s[k:])
+	slices.Sort(mins)
+	return mins
+}
+
+// MaxKCopy returns a new slice with the k biggest elements in s, sorted in descending order.
+//
+// Unlike [MaxK], the original slice is never written to, and the result
+// does not share memory with it. Only min(k, len(s)) elements are allocated.
+func MaxKCopy[E cmp.Ordered](s []E, k int) []E {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	k = min(k, len(s))
+	maxs := make([]E, k)
+	copy(maxs, s[:k])
+
+	maxK(maxs, s[k:])
+	SortDescending(maxs)
+	return maxs
+}
+
+// minK replaces elements of mins with smaller elements of rest, so that mins ends up
+// holding the len(mins) smallest elements of both, in no particular order.
+func minK[E cmp.Ordered](mins, rest []E) {
+	if len(rest) == 0 {
+		return
+	}
+
+	if useHeap(len(mins), len(mins)+len(rest)) {
+		heapMinK(mins, rest)
+	} else {
+		linearMinK(mins, rest)
+	}
+}
+
+// maxK replaces elements of maxs with bigger elements of rest, so that maxs ends up
+// holding the len(maxs) biggest elements of both, in no particular order.
+func maxK[E cmp.Ordered](maxs, rest []E) {
+	if len(rest) == 0 {
+		return
+	}
+
+	if useHeap(len(maxs), len(maxs)+len(rest)) {
+		heapMaxK(maxs, rest)
+	} else {
+		linearMaxK(maxs, rest)
+	}
+}
+
+// linearMinK is the implementation of [minK] that rescans the window after
+// each replacement, which is the fastest approach for small k.
+func linearMinK[E cmp.Ordered](mins, rest []E) {
+	i, max := Max(mins)
+
+	for _, e := range rest {
+		if e < max {
+			// swap out the biggest element with the new one
+			mins[i] = e
+			i, max = Max(mins)
+		}
+	}
+}
+
+// linearMaxK is the implementation of [maxK] that rescans the window after
+// each replacement, which is the fastest approach for small k.
+func linearMaxK[E cmp.Ordered](maxs, rest []E) {
 	i, min := Min(maxs)
 
-	for _, e := range s[k:] {
+	for _, e := range rest {
 		if e > min {
 			// swap out the smallest element with the new one
 			maxs[i] = e
 			i, min = Min(maxs)
 		}
 	}
+}
 
-	slices.Sort(maxs)
-	slices.Reverse(maxs)
-	return maxs
+// heapMinK is the implementation of [minK] that keeps mins as a max-heap,
+// so that each replacement costs O(log k).
+func heapMinK[E cmp.Ordered](mins, rest []E) {
+	heapifyMax(mins)
+
+	for _, e := range rest {
+		if e < mins[0] {
+			// swap out the biggest element with the new one
+			mins[0] = e
+			siftDownMax(mins, 0)
+		}
+	}
+}
+
+// heapMaxK is the implementation of [maxK] that keeps maxs as a min-heap,
+// so that each replacement costs O(log k).
+func heapMaxK[E cmp.Ordered](maxs, rest []E) {
+	heapifyMin(maxs)
+
+	for _, e := range rest {
+		if e > maxs[0] {
+			// swap out the smallest element with the new one
+			maxs[0] = e
+			siftDownMin(maxs, 0)
+		}
+	}
 }
 
 // Min returns the position and value of the minimal element in s.
-// It panics if s is empty.
+// It panics if s is empty. Use [MinNaN] if s can contain NaNs.
 func Min[E cmp.Ordered](s []E) (int, E) {
 	if len(s) == 0 {
 		panic("slicex.Min: empty slice")
@@ -93,7 +197,7 @@ func Min[E cmp.Ordered](s []E) (int, E) {
 }
 
 // Max returns the position and value of the maximal element in s.
-// It panics if s is empty.
+// It panics if s is empty. Use [MaxNaN] if s can contain NaNs.
 func Max[E cmp.Ordered](s []E) (int, E) {
 	if len(s) == 0 {
 		panic("slicex.Max: empty slice")
@@ -109,6 +213,114 @@ func Max[E cmp.Ordered](s []E) (int, E) {
 	return i, max
 }
 
+// MinKFunc returns the k smallest elements in the slice according to the cmp function,
+// sorted in ascending order. The cmp function follows the [slices.SortFunc] convention:
+// it returns a negative number when a < b, a positive number when a > b and zero when a == b.
+//
+// The original slice will be modified, as in [MinK].
+func MinKFunc[E any](s []E, k int, cmp func(a, b E) int) []E {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	if k >= len(s) {
+		slices.SortFunc(s, cmp)
+		return s
+	}
+
+	mins := s[:k]
+	selectFunc(mins, s[k:], cmp)
+	slices.SortFunc(mins, cmp)
+	return mins
+}
+
+// MaxKFunc returns the k biggest elements in the slice according to the cmp function,
+// sorted in descending order. The cmp function follows the [slices.SortFunc] convention.
+//
+// The original slice will be modified, as in [MaxK].
+func MaxKFunc[E any](s []E, k int, cmp func(a, b E) int) []E {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	reverse := func(a, b E) int { return cmp(b, a) }
+	if k >= len(s) {
+		slices.SortFunc(s, reverse)
+		return s
+	}
+
+	maxs := s[:k]
+	selectFunc(maxs, s[k:], reverse)
+	slices.SortFunc(maxs, reverse)
+	return maxs
+}
+
+// selectFunc replaces elements of mins with smaller elements of rest according to cmp,
+// so that mins ends up holding the len(mins) smallest elements of both, in no particular order.
+// Selecting the biggest elements is done by passing the reversed cmp function.
+func selectFunc[E any](mins, rest []E, cmp func(a, b E) int) {
+	if len(rest) == 0 {
+		return
+	}
+
+	if useHeap(len(mins), len(mins)+len(rest)) {
+		heapifyFunc(mins, cmp)
+		for _, e := range rest {
+			if cmp(e, mins[0]) < 0 {
+				// swap out the biggest element with the new one
+				mins[0] = e
+				siftDownFunc(mins, 0, cmp)
+			}
+		}
+		return
+	}
+
+	i, max := MaxFunc(mins, cmp)
+	for _, e := range rest {
+		if cmp(e, max) < 0 {
+			// swap out the biggest element with the new one
+			mins[i] = e
+			i, max = MaxFunc(mins, cmp)
+		}
+	}
+}
+
+// MinFunc returns the position and value of the minimal element in s according to
+// the cmp function. If there are multiple minimal elements, it returns the first one.
+// It panics if s is empty.
+func MinFunc[E any](s []E, cmp func(a, b E) int) (int, E) {
+	if len(s) == 0 {
+		panic("slicex.MinFunc: empty slice")
+	}
+
+	i, min := 0, s[0]
+	for j, e := range s {
+		if cmp(e, min) < 0 {
+			i = j
+			min = e
+		}
+	}
+	return i, min
+}
+
+// MaxFunc returns the position and value of the maximal element in s according to
+// the cmp function. If there are multiple maximal elements, it returns the first one.
+// It panics if s is empty.
+func MaxFunc[E any](s []E, cmp func(a, b E) int) (int, E) {
+	if len(s) == 0 {
+		panic("slicex.MaxFunc: empty slice")
+	}
+
+	i, max := 0, s[0]
+	for j, e := range s {
+		if cmp(e, max) > 0 {
+			i = j
+			max = e
+		}
+	}
+	return i, max
+}
+
 // Pair represents a key-value pair, optimized for scenarios where sorting
 // or k-element selection (MaxK/MinK) is performed based solely on the Val field.
 //
@@ -148,8 +360,28 @@ func ToPairs[K comparable, V cmp.Ordered](m map[K]V) Pairs[K, V] {
 	return pairs
 }
 
+// CollectPairs collects the key-value pairs of the sequence into [Pairs].
+func CollectPairs[K comparable, V cmp.Ordered](seq iter.Seq2[K, V]) Pairs[K, V] {
+	var pairs Pairs[K, V]
+	for k, v := range seq {
+		pairs = append(pairs, Pair[K, V]{Key: k, Val: v})
+	}
+	return pairs
+}
+
 func (p Pairs[K, V]) Len() int { return len(p) }
 
+// All returns an iterator over the key-value pairs, in order.
+func (p Pairs[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, pair := range p {
+			if !yield(pair.Key, pair.Val) {
+				return
+			}
+		}
+	}
+}
+
 // Keys returns the slice of keys of the pairs.
 func (p Pairs[K, V]) Keys() []K {
 	keys := make([]K, len(p))
@@ -235,7 +467,9 @@ func (p Pairs[K, V]) SortDescending() {
 
 // MinK returns the k smallest pairs by value, sorted in ascending order.
 //
-// The original pairs will be modified.
+// The original pairs will be modified: the result is p[:min(k, len(p))], so it
+// aliases the backing array of p, and the order of the pairs is not preserved.
+// Use [Pairs.MinKCopy] to leave p untouched.
 func (p Pairs[K, V]) MinK(k int) Pairs[K, V] {
 	if k < 1 || len(p) == 0 {
 		return nil
@@ -247,23 +481,16 @@ func (p Pairs[K, V]) MinK(k int) Pairs[K, V] {
 	}
 
 	mins := p[:k]
-	i, max := mins.maxVal()
-
-	for _, e := range p[k:] {
-		if e.Val < max {
-			// swap out the biggest element with the new one
-			mins[i] = e
-			i, max = mins.maxVal()
-		}
-	}
-
+	mins.minK(p[k:])
 	mins.SortAscending()
 	return mins
 }
 
 // MaxK returns the k biggest pairs by value, sorted in descending order.
 //
-// The original pairs will be modified.
+// The original pairs will be modified: the result is p[:min(k, len(p))], so it
+// aliases the backing array of p, and the order of the pairs is not preserved.
+// Use [Pairs.MaxKCopy] to leave p untouched.
 func (p Pairs[K, V]) MaxK(k int) Pairs[K, V] {
 	if k < 1 || len(p) == 0 {
 		return nil
@@ -275,18 +502,125 @@ func (p Pairs[K, V]) MaxK(k int) Pairs[K, V] {
 	}
 
 	maxs := p[:k]
-	i, min := maxs.minVal()
+	maxs.maxK(p[k:])
+	maxs.SortDescending()
+	return maxs
+}
+
+// MinKCopy returns new pairs with the k smallest pairs by value, sorted in ascending order.
+//
+// Unlike [Pairs.MinK], the original pairs are never written to, and the result
+// does not share memory with them. Only min(k, len(p)) pairs are allocated.
+func (p Pairs[K, V]) MinKCopy(k int) Pairs[K, V] {
+	if k < 1 || len(p) == 0 {
+		return nil
+	}
+
+	k = min(k, len(p))
+	mins := make(Pairs[K, V], k)
+	copy(mins, p[:k])
+
+	mins.minK(p[k:])
+	mins.SortAscending()
+	return mins
+}
+
+// MaxKCopy returns new pairs with the k biggest pairs by value, sorted in descending order.
+//
+// Unlike [Pairs.MaxK], the original pairs are never written to, and the result
+// does not share memory with them. Only min(k, len(p)) pairs are allocated.
+func (p Pairs[K, V]) MaxKCopy(k int) Pairs[K, V] {
+	if k < 1 || len(p) == 0 {
+		return nil
+	}
+
+	k = min(k, len(p))
+	maxs := make(Pairs[K, V], k)
+	copy(maxs, p[:k])
+
+	maxs.maxK(p[k:])
+	maxs.SortDescending()
+	return maxs
+}
+
+// minK replaces pairs of p with pairs of rest with smaller values, so that p ends up
+// holding the len(p) smallest pairs of both, in no particular order.
+func (p Pairs[K, V]) minK(rest Pairs[K, V]) {
+	if len(rest) == 0 {
+		return
+	}
+
+	if useHeap(len(p), len(p)+len(rest)) {
+		p.heapMinK(rest)
+	} else {
+		p.linearMinK(rest)
+	}
+}
+
+// maxK replaces pairs of p with pairs of rest with bigger values, so that p ends up
+// holding the len(p) biggest pairs of both, in no particular order.
+func (p Pairs[K, V]) maxK(rest Pairs[K, V]) {
+	if len(rest) == 0 {
+		return
+	}
 
-	for _, e := range p[k:] {
+	if useHeap(len(p), len(p)+len(rest)) {
+		p.heapMaxK(rest)
+	} else {
+		p.linearMaxK(rest)
+	}
+}
+
+// linearMinK is the implementation of [Pairs.minK] that rescans p after each replacement.
+func (p Pairs[K, V]) linearMinK(rest Pairs[K, V]) {
+	i, max := p.maxVal()
+
+	for _, e := range rest {
+		if e.Val < max {
+			// swap out the biggest element with the new one
+			p[i] = e
+			i, max = p.maxVal()
+		}
+	}
+}
+
+// linearMaxK is the implementation of [Pairs.maxK] that rescans p after each replacement.
+func (p Pairs[K, V]) linearMaxK(rest Pairs[K, V]) {
+	i, min := p.minVal()
+
+	for _, e := range rest {
 		if e.Val > min {
 			// swap out the smallest element with the new one
-			maxs[i] = e
-			i, min = maxs.minVal()
+			p[i] = e
+			i, min = p.minVal()
 		}
 	}
+}
 
-	maxs.SortDescending()
-	return maxs
+// heapMinK is the implementation of [Pairs.minK] that keeps p as a max-heap.
+func (p Pairs[K, V]) heapMinK(rest Pairs[K, V]) {
+	p.heapifyMax()
+
+	for _, e := range rest {
+		if e.Val < p[0].Val {
+			// swap out the biggest element with the new one
+			p[0] = e
+			p.siftDownMax(0)
+		}
+	}
+}
+
+// heapMaxK is the implementation of [Pairs.maxK] that keeps p as a min-heap.
+func (p Pairs[K, V]) heapMaxK(rest Pairs[K, V]) {
+	p.heapifyMin()
+
+	for _, e := range rest {
+		if e.Val > p[0].Val {
+			// swap out the smallest element with the new one
+			p[0] = e
+			p.siftDownMin(0)
+		}
+	}
 }
 
 func (p Pairs[K, V]) minVal() (int, V) {
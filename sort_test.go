@@ -187,6 +187,301 @@ func TestPairsMaxK(t *testing.T) {
 	})
 }
 
+func TestMinKCopy(t *testing.T) {
+	const iter = 1000
+	const size = 1000
+
+	for range iter {
+		k := rand.IntN(size)
+		s := RandomFloats(rand.IntN(size) + 1)
+		original := slices.Clone(s)
+
+		mins := MinKCopy(s, k)
+		expected := MinKNaive(slices.Clone(s), k)
+
+		if !reflect.DeepEqual(mins, expected) {
+			t.Errorf("len(s) = %d; k = %d", len(s), k)
+			t.Fatalf("expected mins %v, got %v", expected, mins)
+		}
+
+		if !reflect.DeepEqual(s, original) {
+			t.Fatalf("len(s) = %d; k = %d: the original slice was modified", len(s), k)
+		}
+
+		if len(mins) > 0 && &mins[0] == &s[0] {
+			t.Fatalf("len(s) = %d; k = %d: the result aliases the original slice", len(s), k)
+		}
+	}
+}
+
+func TestMaxKCopy(t *testing.T) {
+	const iter = 1000
+	const size = 1000
+
+	for range iter {
+		k := rand.IntN(size)
+		s := RandomFloats(rand.IntN(size) + 1)
+		original := slices.Clone(s)
+
+		maxs := MaxKCopy(s, k)
+		expected := MaxKNaive(slices.Clone(s), k)
+
+		if !reflect.DeepEqual(maxs, expected) {
+			t.Errorf("len(s) = %d; k = %d", len(s), k)
+			t.Fatalf("expected maxs %v, got %v", expected, maxs)
+		}
+
+		if !reflect.DeepEqual(s, original) {
+			t.Fatalf("len(s) = %d; k = %d: the original slice was modified", len(s), k)
+		}
+
+		if len(maxs) > 0 && &maxs[0] == &s[0] {
+			t.Fatalf("len(s) = %d; k = %d: the result aliases the original slice", len(s), k)
+		}
+	}
+}
+
+func TestPairsMinKCopy(t *testing.T) {
+	const iter = 1000
+	const size = 1000
+
+	for range iter {
+		k := rand.IntN(size)
+		p := toPairs(RandomFloats(rand.IntN(size) + 1))
+		original := slices.Clone(p)
+
+		mins := p.MinKCopy(k)
+		expected := slices.Clone(p).MinKNaive(k)
+
+		if !reflect.DeepEqual(mins, expected) {
+			t.Errorf("len(p) = %d; k = %d", len(p), k)
+			t.Fatalf("expected mins %v, got %v", expected, mins)
+		}
+
+		if !reflect.DeepEqual(p, original) {
+			t.Fatalf("len(p) = %d; k = %d: the original pairs were modified", len(p), k)
+		}
+	}
+}
+
+func TestPairsMaxKCopy(t *testing.T) {
+	const iter = 1000
+	const size = 1000
+
+	for range iter {
+		k := rand.IntN(size)
+		p := toPairs(RandomFloats(rand.IntN(size) + 1))
+		original := slices.Clone(p)
+
+		maxs := p.MaxKCopy(k)
+		expected := slices.Clone(p).MaxKNaive(k)
+
+		if !reflect.DeepEqual(maxs, expected) {
+			t.Errorf("len(p) = %d; k = %d", len(p), k)
+			t.Fatalf("expected maxs %v, got %v", expected, maxs)
+		}
+
+		if !reflect.DeepEqual(p, original) {
+			t.Fatalf("len(p) = %d; k = %d: the original pairs were modified", len(p), k)
+		}
+	}
+}
+
+func TestMinKFunc(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		type score struct {
+			name string
+			val  int
+		}
+
+		byVal := func(a, b score) int { return cmp.Compare(a.val, b.val) }
+		tests := []struct {
+			s        []score
+			k        int
+			expected []score
+		}{
+			{s: nil, k: 1, expected: nil},
+			{s: []score{{"a", 0}}, k: -1, expected: nil},
+			{s: []score{{"a", 2}, {"b", 0}, {"c", 1}}, k: 10, expected: []score{{"b", 0}, {"c", 1}, {"a", 2}}},
+			{s: []score{{"a", 2}, {"b", 0}, {"c", 1}, {"d", -5}}, k: 2, expected: []score{{"d", -5}, {"b", 0}}},
+		}
+
+		for i, test := range tests {
+			mins := MinKFunc(test.s, test.k, byVal)
+			if !reflect.DeepEqual(mins, test.expected) {
+				t.Fatalf("test %d: expected mins %v, got %v", i, test.expected, mins)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		const iter = 1000
+		const size = 1000
+
+		for range iter {
+			k := rand.IntN(size)
+			s := RandomFloats(rand.IntN(size) + 1)
+
+			mins := MinKFunc(slices.Clone(s), k, cmp.Compare[float64])
+			expected := MinKNaive(s, k)
+
+			if !reflect.DeepEqual(mins, expected) {
+				t.Errorf("len(s) = %d; k = %d", len(s), k)
+				t.Fatalf("expected mins %v, got %v", expected, mins)
+			}
+		}
+	})
+}
+
+func TestMaxKFunc(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		type score struct {
+			name string
+			val  int
+		}
+
+		byVal := func(a, b score) int { return cmp.Compare(a.val, b.val) }
+		tests := []struct {
+			s        []score
+			k        int
+			expected []score
+		}{
+			{s: nil, k: 1, expected: nil},
+			{s: []score{{"a", 0}}, k: -1, expected: nil},
+			{s: []score{{"a", 2}, {"b", 0}, {"c", 1}}, k: 10, expected: []score{{"a", 2}, {"c", 1}, {"b", 0}}},
+			{s: []score{{"a", 2}, {"b", 0}, {"c", 1}, {"d", -5}}, k: 2, expected: []score{{"a", 2}, {"c", 1}}},
+		}
+
+		for i, test := range tests {
+			maxs := MaxKFunc(test.s, test.k, byVal)
+			if !reflect.DeepEqual(maxs, test.expected) {
+				t.Fatalf("test %d: expected maxs %v, got %v", i, test.expected, maxs)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		const iter = 1000
+		const size = 1000
+
+		for range iter {
+			k := rand.IntN(size)
+			s := RandomFloats(rand.IntN(size) + 1)
+
+			maxs := MaxKFunc(slices.Clone(s), k, cmp.Compare[float64])
+			expected := MaxKNaive(s, k)
+
+			if !reflect.DeepEqual(maxs, expected) {
+				t.Errorf("len(s) = %d; k = %d", len(s), k)
+				t.Fatalf("expected maxs %v, got %v", expected, maxs)
+			}
+		}
+	})
+}
+
+func TestMinMaxFunc(t *testing.T) {
+	tests := []struct {
+		s              []int
+		minPos, maxPos int
+	}{
+		{s: []int{7}, minPos: 0, maxPos: 0},
+		{s: []int{3, 1, 4, 1, 5, 9, 2, 9}, minPos: 1, maxPos: 5},
+		{s: []int{-2, -2, -2}, minPos: 0, maxPos: 0},
+	}
+
+	for i, test := range tests {
+		minPos, min := MinFunc(test.s, cmp.Compare[int])
+		if minPos != test.minPos || min != test.s[test.minPos] {
+			t.Errorf("test %d: expected min at %d, got %d", i, test.minPos, minPos)
+		}
+
+		maxPos, max := MaxFunc(test.s, cmp.Compare[int])
+		if maxPos != test.maxPos || max != test.s[test.maxPos] {
+			t.Errorf("test %d: expected max at %d, got %d", i, test.maxPos, maxPos)
+		}
+	}
+}
+
+func TestKSelectionAliasing(t *testing.T) {
+	s := []int{5, 3, 9, 1, 7}
+
+	mins := MinK(s, 2)
+	if &mins[0] != &s[0] {
+		t.Fatalf("MinK: expected the result to alias the original slice")
+	}
+
+	maxs := MaxK(s, 2)
+	if &maxs[0] != &s[0] {
+		t.Fatalf("MaxK: expected the result to alias the original slice")
+	}
+
+	p := toPairs(s)
+	if pmins := p.MinK(2); &pmins[0] != &p[0] {
+		t.Fatalf("Pairs.MinK: expected the result to alias the original pairs")
+	}
+
+	if pmaxs := p.MaxK(2); &pmaxs[0] != &p[0] {
+		t.Fatalf("Pairs.MaxK: expected the result to alias the original pairs")
+	}
+}
+
+func TestHeapSelection(t *testing.T) {
+	const iter = 1000
+	const size = 1000
+
+	for range iter {
+		s := RandomFloats(rand.IntN(size) + 2)
+		k := rand.IntN(len(s)-1) + 1
+
+		mins := slices.Clone(s)
+		heapMinK(mins[:k], mins[k:])
+		slices.Sort(mins[:k])
+
+		if expected := MinKNaive(slices.Clone(s), k); !reflect.DeepEqual(mins[:k], expected) {
+			t.Fatalf("len(s) = %d; k = %d: expected mins %v, got %v", len(s), k, expected, mins[:k])
+		}
+
+		maxs := slices.Clone(s)
+		heapMaxK(maxs[:k], maxs[k:])
+		SortDescending(maxs[:k])
+
+		if expected := MaxKNaive(slices.Clone(s), k); !reflect.DeepEqual(maxs[:k], expected) {
+			t.Fatalf("len(s) = %d; k = %d: expected maxs %v, got %v", len(s), k, expected, maxs[:k])
+		}
+
+		p := toPairs(s)
+		p[:k].heapMaxK(p[k:])
+		p[:k].SortDescending()
+
+		if expected := toPairs(s).MaxKNaive(k); !reflect.DeepEqual(p[:k].Vals(), expected.Vals()) {
+			t.Fatalf("len(p) = %d; k = %d: expected maxs %v, got %v", len(s), k, expected, p[:k])
+		}
+
+		p = toPairs(s)
+		p[:k].heapMinK(p[k:])
+		p[:k].SortAscending()
+
+		if expected := toPairs(s).MinKNaive(k); !reflect.DeepEqual(p[:k].Vals(), expected.Vals()) {
+			t.Fatalf("len(p) = %d; k = %d: expected mins %v, got %v", len(s), k, expected, p[:k])
+		}
+	}
+}
+
+func TestPairsAll(t *testing.T) {
+	tests := []Pairs[string, int]{
+		nil,
+		{{Key: "a", Val: 1}},
+		{{Key: "a", Val: 1}, {Key: "b", Val: -2}, {Key: "a", Val: 3}},
+	}
+
+	for i, pairs := range tests {
+		collected := CollectPairs(pairs.All())
+		if len(collected) != len(pairs) || (len(pairs) > 0 && !reflect.DeepEqual(collected, pairs)) {
+			t.Errorf("test %d: expected %v, got %v", i, pairs, collected)
+		}
+	}
+}
+
 func toPairs[E cmp.Ordered](s []E) Pairs[int, E] {
 	p := make(Pairs[int, E], len(s))
 	for i, e := range s {
@@ -289,6 +584,18 @@ func BenchmarkMaxKNaive(b *testing.B) {
 	}
 }
 
+func BenchmarkMaxKFunc(b *testing.B) {
+	for _, bench := range SortBenchs {
+		b.Run(fmt.Sprintf("max_10/%d", len(bench)), func(b *testing.B) {
+			for range b.N {
+				c := make([]float64, len(bench))
+				copy(c, bench)
+				MaxKFunc(c, 10, cmp.Compare[float64])
+			}
+		})
+	}
+}
+
 func BenchmarkPairsMinK(b *testing.B) {
 	for _, bench := range SortBenchs {
 		b.Run(fmt.Sprintf("min_10/%d", len(bench)), func(b *testing.B) {
@@ -394,3 +701,33 @@ func ascendingPairs[K comparable, V cmp.Ordered](a, b Pair[K, V]) int {
 func descendingPairs[K comparable, V cmp.Ordered](a, b Pair[K, V]) int {
 	return cmp.Compare(b.Val, a.Val)
 }
+
+func BenchmarkMinKCrossover(b *testing.B) {
+	sizes := []int{10_000, 1_000_000}
+	ks := []int{8, 16, 32, 64, 128, 1000, 5000}
+
+	for _, size := range sizes {
+		bench := RandomFloats(size)
+		for _, k := range ks {
+			if k >= size {
+				continue
+			}
+
+			b.Run(fmt.Sprintf("linear/k=%d/%d", k, size), func(b *testing.B) {
+				c := make([]float64, size)
+				for range b.N {
+					copy(c, bench)
+					linearMinK(c[:k], c[k:])
+				}
+			})
+
+			b.Run(fmt.Sprintf("heap/k=%d/%d", k, size), func(b *testing.B) {
+				c := make([]float64, size)
+				for range b.N {
+					copy(c, bench)
+					heapMinK(c[:k], c[k:])
+				}
+			})
+		}
+	}
+}
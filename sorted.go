@@ -0,0 +1,365 @@
+package slicex
+
+import (
+	"cmp"
+	"slices"
+)
+
+// This file contains set operations for slices sorted in ascending order, as with [slices.Sort].
+// The inputs can contain duplicates, and the results are unique and sorted in ascending order.
+// They are computed with linear merges, with no map allocations.
+// Elements are compared as with [cmp.Less], so NaNs are equal to each other and smaller than
+// any other value, which is where [slices.Sort] puts them.
+// If an input is not sorted, the result is unspecified.
+
+// SortedUnique returns a new slice with no duplicates of the sorted slice s.
+func SortedUnique[E cmp.Ordered](s []E) []E {
+	unique := make([]E, 0, len(s))
+	for i, e := range s {
+		if i == 0 || cmp.Less(s[i-1], e) {
+			unique = append(unique, e)
+		}
+	}
+	return unique
+}
+
+// UniqueSortedInPlace removes the duplicates from the sorted slice s, with no allocations.
+// It reuses the backing array of s, whose vacated tail is zeroed for GC safety, and
+// returns the shortened slice.
+func UniqueSortedInPlace[E comparable](s []E) []E {
+	return slices.Compact(s)
+}
+
+// SortedUnion returns a new slice containing unique elements found in any of the sorted slices.
+func SortedUnion[E cmp.Ordered](inputs ...[]E) []E {
+	switch len(inputs) {
+	case 0:
+		return []E{}
+
+	case 1:
+		return SortedUnique(inputs[0])
+
+	default:
+		// merging in pairs, so that each element is merged O(log n) times
+		for len(inputs) > 1 {
+			merged := make([][]E, 0, (len(inputs)+1)/2)
+			for i := 0; i < len(inputs); i += 2 {
+				if i+1 == len(inputs) {
+					merged = append(merged, inputs[i])
+					break
+				}
+				merged = append(merged, sortedUnion2(inputs[i], inputs[i+1]))
+			}
+			inputs = merged
+		}
+		return inputs[0]
+	}
+}
+
+func sortedUnion2[E cmp.Ordered](s1, s2 []E) []E {
+	union := make([]E, 0, len(s1)+len(s2))
+	i, j := 0, 0
+
+	for i < len(s1) && j < len(s2) {
+		switch {
+		case cmp.Less(s1[i], s2[j]):
+			union = appendIfNew(union, s1[i])
+			i++
+
+		case cmp.Less(s2[j], s1[i]):
+			union = appendIfNew(union, s2[j])
+			j++
+
+		default:
+			union = appendIfNew(union, s1[i])
+			i++
+			j++
+		}
+	}
+
+	for _, e := range s1[i:] {
+		union = appendIfNew(union, e)
+	}
+
+	for _, e := range s2[j:] {
+		union = appendIfNew(union, e)
+	}
+	return union
+}
+
+// SortedIntersection returns a new slice containing unique elements found in all sorted slices.
+//
+// It intersects starting from the smallest slice, galloping through the slices that are much bigger,
+// so that its cost scales with the length of the smallest slice rather than the biggest.
+func SortedIntersection[E cmp.Ordered](inputs ...[]E) []E {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	// intersecting from the smallest slice
+	i := smallest(inputs)
+	inter := SortedUnique(inputs[i])
+	for j, s := range inputs {
+		if len(inter) == 0 {
+			break
+		}
+
+		if j != i {
+			inter = sortedIntersect2(inter, s)
+		}
+	}
+	return inter
+}
+
+// gallopRatio is the minimal ratio between the lengths of the two slices being intersected
+// for which galloping through the bigger one is faster than a linear merge.
+// See the galloping benchmarks in bench.md.
+const gallopRatio = 8
+
+// sortedIntersect2 intersects the unique sorted inter with the sorted s, writing the
+// result into the prefix of inter, which is returned.
+func sortedIntersect2[E cmp.Ordered](inter, s []E) []E {
+	if len(s) >= gallopRatio*len(inter) {
+		return gallopIntersect2(inter, s)
+	}
+	return mergeIntersect2(inter, s)
+}
+
+// mergeIntersect2 is the implementation of [sortedIntersect2] that merges the two slices
+// linearly, in O(len(inter) + len(s)).
+func mergeIntersect2[E cmp.Ordered](inter, s []E) []E {
+	n, i, j := 0, 0, 0
+	for i < len(inter) && j < len(s) {
+		switch {
+		case cmp.Less(inter[i], s[j]):
+			i++
+
+		case cmp.Less(s[j], inter[i]):
+			j++
+
+		default:
+			inter[n] = inter[i]
+			n++
+			i++
+			j++
+		}
+	}
+	return inter[:n]
+}
+
+// gallopIntersect2 is the implementation of [sortedIntersect2] that looks up each element
+// of inter in s with an exponential search followed by a binary search, starting from the
+// position of the previous one. It runs in O(m·log(n/m)), where m = len(inter) and n = len(s),
+// which is much faster than the linear merge when n is much bigger than m.
+func gallopIntersect2[E cmp.Ordered](inter, s []E) []E {
+	n, j := 0, 0
+	for _, e := range inter {
+		j = gallop(s, j, e)
+		if j == len(s) {
+			break
+		}
+
+		if !cmp.Less(e, s[j]) {
+			inter[n] = e
+			n++
+			j++
+		}
+	}
+	return inter[:n]
+}
+
+// gallop returns the smallest position i >= start such that s[i] >= e as in [cmp.Less],
+// or len(s) if there is none. It assumes that s is sorted.
+func gallop[E cmp.Ordered](s []E, start int, e E) int {
+	if start >= len(s) || !cmp.Less(s[start], e) {
+		return start
+	}
+
+	// s[lo] < e, so we double the step until s[hi] >= e
+	lo, step := start, 1
+	hi := lo + step
+	for hi < len(s) && cmp.Less(s[hi], e) {
+		lo = hi
+		step *= 2
+		hi = lo + step
+	}
+
+	hi = min(hi, len(s))
+	i, _ := slices.BinarySearch(s[lo+1:hi], e)
+	return lo + 1 + i
+}
+
+// SortedDifference returns a new slice containing unique elements of the sorted s1 not in the sorted s2.
+func SortedDifference[E cmp.Ordered](s1, s2 []E) []E {
+	diff := make([]E, 0, len(s1))
+	j := 0
+
+	for _, e := range s1 {
+		for j < len(s2) && cmp.Less(s2[j], e) {
+			j++
+		}
+
+		if j == len(s2) || cmp.Less(e, s2[j]) {
+			diff = appendIfNew(diff, e)
+		}
+	}
+	return diff
+}
+
+// SortedSymmetricDifference returns a new slice of unique elements present in either
+// the sorted s1 or the sorted s2, but not both.
+func SortedSymmetricDifference[E cmp.Ordered](s1, s2 []E) []E {
+	diff := make([]E, 0, len(s1)+len(s2))
+	i, j := 0, 0
+
+	for i < len(s1) && j < len(s2) {
+		switch {
+		case cmp.Less(s1[i], s2[j]):
+			diff = appendIfNew(diff, s1[i])
+			i++
+
+		case cmp.Less(s2[j], s1[i]):
+			diff = appendIfNew(diff, s2[j])
+			j++
+
+		default:
+			i, j = skipEqual(s1, s2, i, j)
+		}
+	}
+
+	for _, e := range s1[i:] {
+		diff = appendIfNew(diff, e)
+	}
+
+	for _, e := range s2[j:] {
+		diff = appendIfNew(diff, e)
+	}
+	return diff
+}
+
+// SortedPartition returns three unique and non-overlapping slices: elements only in the sorted s1,
+// elements in both, and elements only in the sorted s2.
+func SortedPartition[E cmp.Ordered](s1, s2 []E) (d12, inter, d21 []E) {
+	d12 = make([]E, 0, len(s1))
+	inter = make([]E, 0, min(len(s1), len(s2)))
+	d21 = make([]E, 0, len(s2))
+
+	i, j := 0, 0
+	for i < len(s1) && j < len(s2) {
+		switch {
+		case cmp.Less(s1[i], s2[j]):
+			d12 = appendIfNew(d12, s1[i])
+			i++
+
+		case cmp.Less(s2[j], s1[i]):
+			d21 = appendIfNew(d21, s2[j])
+			j++
+
+		default:
+			inter = append(inter, s1[i])
+			i, j = skipEqual(s1, s2, i, j)
+		}
+	}
+
+	for _, e := range s1[i:] {
+		d12 = appendIfNew(d12, e)
+	}
+
+	for _, e := range s2[j:] {
+		d21 = appendIfNew(d21, e)
+	}
+
+	return d12, inter, d21
+}
+
+// SortedIsSubset reports whether all the elements of the sorted a are in the sorted b.
+func SortedIsSubset[E cmp.Ordered](a, b []E) bool {
+	j := 0
+	for _, e := range a {
+		j = gallop(b, j, e)
+		if j == len(b) || cmp.Less(e, b[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SortedIsSuperset reports whether all the elements of the sorted b are in the sorted a.
+func SortedIsSuperset[E cmp.Ordered](a, b []E) bool {
+	return SortedIsSubset(b, a)
+}
+
+// SortedIsDisjoint reports whether the sorted a and b have no elements in common.
+func SortedIsDisjoint[E cmp.Ordered](a, b []E) bool {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case cmp.Less(a[i], b[j]):
+			i++
+
+		case cmp.Less(b[j], a[i]):
+			j++
+
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// SortedEqualSets reports whether the sorted a and b contain the same elements, regardless of duplicates.
+func SortedEqualSets[E cmp.Ordered](a, b []E) bool {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp.Compare(a[i], b[j]) != 0 {
+			return false
+		}
+		i, j = skipEqual(a, b, i, j)
+	}
+	return i == len(a) && j == len(b)
+}
+
+// SortedOverlap returns the number of unique elements that the sorted a and b have in common,
+// which is the length of their [SortedIntersection], without building it.
+func SortedOverlap[E cmp.Ordered](a, b []E) int {
+	var overlap int
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case cmp.Less(a[i], b[j]):
+			i++
+
+		case cmp.Less(b[j], a[i]):
+			j++
+
+		default:
+			overlap++
+			i, j = skipEqual(a, b, i, j)
+		}
+	}
+	return overlap
+}
+
+// appendIfNew appends e to the sorted unique s, unless it is equal to its last element.
+// Since e is never smaller than the last element, they are equal if it's not bigger.
+func appendIfNew[E cmp.Ordered](s []E, e E) []E {
+	if len(s) > 0 && !cmp.Less(s[len(s)-1], e) {
+		return s
+	}
+	return append(s, e)
+}
+
+// skipEqual advances i and j past all the elements equal to s1[i], which is equal to s2[j].
+// It always advances both by at least one.
+func skipEqual[E cmp.Ordered](s1, s2 []E, i, j int) (int, int) {
+	e := s1[i]
+	i, j = i+1, j+1
+	for i < len(s1) && !cmp.Less(e, s1[i]) {
+		i++
+	}
+	for j < len(s2) && !cmp.Less(e, s2[j]) {
+		j++
+	}
+	return i, j
+}
@@ -0,0 +1,347 @@
+package slicex
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSortedUnique(t *testing.T) {
+	tests := []struct {
+		slice    []int
+		expected []int
+	}{
+		{slice: nil, expected: []int{}},
+		{slice: []int{}, expected: []int{}},
+		{slice: []int{0, 1, 2}, expected: []int{0, 1, 2}},
+		{slice: []int{0, 0, 1, 1, 1, 2, 3, 3}, expected: []int{0, 1, 2, 3}},
+	}
+
+	for i, test := range tests {
+		unique := SortedUnique(test.slice)
+		if !reflect.DeepEqual(unique, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, unique)
+		}
+	}
+}
+
+func TestUniqueSortedInPlace(t *testing.T) {
+	tests := []struct {
+		slice    []int
+		expected []int
+	}{
+		{slice: nil, expected: nil},
+		{slice: []int{}, expected: []int{}},
+		{slice: []int{0, 1, 2}, expected: []int{0, 1, 2}},
+		{slice: []int{0, 0, 1, 1, 1, 2, 3, 3}, expected: []int{0, 1, 2, 3}},
+	}
+
+	for i, test := range tests {
+		original := test.slice
+		unique := UniqueSortedInPlace(test.slice)
+		if !reflect.DeepEqual(unique, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, unique)
+		}
+
+		if !isZero(original[len(unique):]) {
+			t.Errorf("test %d: the vacated tail %v is not zeroed", i, original[len(unique):])
+		}
+	}
+}
+
+func TestSortedOperations(t *testing.T) {
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 1000 {
+			s1 := RandomSortedInts(rand.IntN(100), 50)
+			s2 := RandomSortedInts(rand.IntN(100), 50)
+			s3 := RandomSortedInts(rand.IntN(100), 50)
+
+			if union, expected := SortedUnion(s1, s2, s3), sorted(Union(s1, s2, s3)); !reflect.DeepEqual(union, expected) {
+				t.Fatalf("SortedUnion(%v, %v, %v): expected %v, got %v", s1, s2, s3, expected, union)
+			}
+
+			if inter, expected := SortedIntersection(s1, s2, s3), sorted(Intersection(s1, s2, s3)); !reflect.DeepEqual(inter, expected) {
+				t.Fatalf("SortedIntersection(%v, %v, %v): expected %v, got %v", s1, s2, s3, expected, inter)
+			}
+
+			if diff, expected := SortedDifference(s1, s2), sorted(Difference(s1, s2)); !reflect.DeepEqual(diff, expected) {
+				t.Fatalf("SortedDifference(%v, %v): expected %v, got %v", s1, s2, expected, diff)
+			}
+
+			if diff, expected := SortedSymmetricDifference(s1, s2), sorted(SymmetricDifference(s1, s2)); !reflect.DeepEqual(diff, expected) {
+				t.Fatalf("SortedSymmetricDifference(%v, %v): expected %v, got %v", s1, s2, expected, diff)
+			}
+
+			d12, inter, d21 := SortedPartition(s1, s2)
+			e12, eInter, e21 := Partition(s1, s2)
+			if !reflect.DeepEqual(d12, sorted(e12)) || !reflect.DeepEqual(inter, sorted(eInter)) || !reflect.DeepEqual(d21, sorted(e21)) {
+				t.Fatalf("SortedPartition(%v, %v): expected (%v,%v,%v), got (%v,%v,%v)", s1, s2, e12, eInter, e21, d12, inter, d21)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		// NaNs are equal to each other and come first, as with slices.Sort
+		s1 := []float64{nan, nan, ninf, 1, 2, inf}
+		s2 := []float64{nan, 2, 3, inf, inf}
+		large := []float64{nan, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+		tests := []struct {
+			name             string
+			result, expected []float64
+		}{
+			{name: "SortedUnique", result: SortedUnique(s1), expected: []float64{nan, ninf, 1, 2, inf}},
+			{name: "SortedUnion", result: SortedUnion(s1, s2), expected: []float64{nan, ninf, 1, 2, 3, inf}},
+			{name: "SortedIntersection", result: SortedIntersection(s1, s2), expected: []float64{nan, 2, inf}},
+			{name: "SortedIntersection", result: SortedIntersection([]float64{nan}, []float64{1}), expected: []float64{}},
+			{name: "SortedIntersection", result: SortedIntersection([]float64{nan, 5}, large), expected: []float64{nan, 5}},
+			{name: "SortedDifference", result: SortedDifference(s1, s2), expected: []float64{ninf, 1}},
+			{name: "SortedDifference", result: SortedDifference(s1, []float64{1}), expected: []float64{nan, ninf, 2, inf}},
+			{name: "SortedSymmetricDifference", result: SortedSymmetricDifference(s1, s2), expected: []float64{ninf, 1, 3}},
+			{name: "SortedSymmetricDifference", result: SortedSymmetricDifference([]float64{nan}, nil), expected: []float64{nan}},
+		}
+
+		for _, test := range tests {
+			if !equalNaN(test.result, test.expected) {
+				t.Errorf("%s: expected %v, got %v", test.name, test.expected, test.result)
+			}
+		}
+
+		d12, inter, d21 := SortedPartition(s1, s2)
+		if !equalNaN(d12, []float64{ninf, 1}) || !equalNaN(inter, []float64{nan, 2, inf}) || !equalNaN(d21, []float64{3}) {
+			t.Errorf("SortedPartition: expected ([-Inf 1], [NaN 2 +Inf], [3]), got (%v, %v, %v)", d12, inter, d21)
+		}
+	})
+}
+
+func TestSortedRelations(t *testing.T) {
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 1000 {
+			a := RandomSortedInts(rand.IntN(10), 10)
+			b := RandomSortedInts(rand.IntN(20), 10)
+
+			if subset, expected := SortedIsSubset(a, b), IsSubset(a, b); subset != expected {
+				t.Fatalf("SortedIsSubset(%v, %v): expected %v, got %v", a, b, expected, subset)
+			}
+
+			if superset, expected := SortedIsSuperset(a, b), IsSuperset(a, b); superset != expected {
+				t.Fatalf("SortedIsSuperset(%v, %v): expected %v, got %v", a, b, expected, superset)
+			}
+
+			if disjoint, expected := SortedIsDisjoint(a, b), IsDisjoint(a, b); disjoint != expected {
+				t.Fatalf("SortedIsDisjoint(%v, %v): expected %v, got %v", a, b, expected, disjoint)
+			}
+
+			if equal, expected := SortedEqualSets(a, b), EqualSets(a, b); equal != expected {
+				t.Fatalf("SortedEqualSets(%v, %v): expected %v, got %v", a, b, expected, equal)
+			}
+
+			if overlap, expected := SortedOverlap(a, b), Overlap(a, b); overlap != expected {
+				t.Fatalf("SortedOverlap(%v, %v): expected %v, got %v", a, b, expected, overlap)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		// NaNs are equal to each other and come first, as with slices.Sort
+		tests := []struct {
+			a, b                              []float64
+			subset, superset, disjoint, equal bool
+			overlap                           int
+		}{
+			{a: []float64{nan}, b: []float64{5}, disjoint: true},
+			{a: []float64{nan}, b: []float64{nan, nan}, subset: true, superset: true, equal: true, overlap: 1},
+			{a: []float64{nan, 1, 2}, b: []float64{1, 3}, overlap: 1},
+			{a: []float64{nan, 1}, b: []float64{nan, nan, 1, inf}, subset: true, overlap: 2},
+			{a: []float64{ninf, inf}, b: []float64{nan, ninf, 0, inf}, subset: true, overlap: 2},
+			{a: []float64{nan, ninf}, b: []float64{inf}, disjoint: true},
+		}
+
+		for i, test := range tests {
+			if subset := SortedIsSubset(test.a, test.b); subset != test.subset {
+				t.Errorf("test %d: SortedIsSubset: expected %v, got %v", i, test.subset, subset)
+			}
+
+			if superset := SortedIsSuperset(test.a, test.b); superset != test.superset {
+				t.Errorf("test %d: SortedIsSuperset: expected %v, got %v", i, test.superset, superset)
+			}
+
+			if disjoint := SortedIsDisjoint(test.a, test.b); disjoint != test.disjoint {
+				t.Errorf("test %d: SortedIsDisjoint: expected %v, got %v", i, test.disjoint, disjoint)
+			}
+
+			if equal := SortedEqualSets(test.a, test.b); equal != test.equal {
+				t.Errorf("test %d: SortedEqualSets: expected %v, got %v", i, test.equal, equal)
+			}
+
+			if overlap := SortedOverlap(test.a, test.b); overlap != test.overlap {
+				t.Errorf("test %d: SortedOverlap: expected %v, got %v", i, test.overlap, overlap)
+			}
+		}
+	})
+}
+
+func TestGallopIntersection(t *testing.T) {
+	const iter = 1000
+
+	for range iter {
+		small := SortedUnique(RandomSortedInts(rand.IntN(20), 10_000))
+		large := RandomSortedInts(rand.IntN(10_000), 10_000)
+
+		inter := gallopIntersect2(slices.Clone(small), large)
+		expected := mergeIntersect2(slices.Clone(small), large)
+
+		if !reflect.DeepEqual(inter, expected) {
+			t.Fatalf("gallopIntersect2(%v, ...): expected %v, got %v", small, expected, inter)
+		}
+	}
+}
+
+func TestGallop(t *testing.T) {
+	s := []int{1, 3, 3, 3, 5, 8, 13, 21}
+	tests := []struct {
+		start, e, expected int
+	}{
+		{start: 0, e: 0, expected: 0},
+		{start: 0, e: 1, expected: 0},
+		{start: 0, e: 3, expected: 1},
+		{start: 2, e: 3, expected: 2},
+		{start: 0, e: 4, expected: 4},
+		{start: 3, e: 21, expected: 7},
+		{start: 0, e: 22, expected: 8},
+		{start: 8, e: 1, expected: 8},
+	}
+
+	for i, test := range tests {
+		if pos := gallop(s, test.start, test.e); pos != test.expected {
+			t.Errorf("test %d: expected %d, got %d", i, test.expected, pos)
+		}
+	}
+}
+
+func RandomSortedInts(size, max int) []int {
+	s := RandomInts(size, max)
+	slices.Sort(s)
+	return s
+}
+
+func sorted[E cmp.Ordered](s []E) []E {
+	slices.Sort(s)
+	return s
+}
+
+// -------------------------------- benchmarks --------------------------------
+
+var SortedSetBenchs []bench
+
+// init sorts the set benchmarks inputs
+func init() {
+	for _, b := range SetBenchs {
+		s1, s2 := slices.Clone(b.s1), slices.Clone(b.s2)
+		slices.Sort(s1)
+		slices.Sort(s2)
+		SortedSetBenchs = append(SortedSetBenchs, bench{size: b.size, s1: s1, s2: s2})
+	}
+}
+
+func BenchmarkSortedUnique(b *testing.B) {
+	for _, bench := range SortedSetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				SortedUnique(bench.s1)
+			}
+		})
+	}
+}
+
+func BenchmarkSortedIntersection(b *testing.B) {
+	for _, bench := range SortedSetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				SortedIntersection(bench.s1, bench.s2, bench.s1[:bench.size/2], bench.s2[:bench.size/2])
+			}
+		})
+	}
+}
+
+func BenchmarkSortedUnion(b *testing.B) {
+	for _, bench := range SortedSetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				SortedUnion(bench.s1, bench.s2, bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkSortedDifference(b *testing.B) {
+	for _, bench := range SortedSetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				SortedDifference(bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkSortedSymmetricDifference(b *testing.B) {
+	for _, bench := range SortedSetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				SortedSymmetricDifference(bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkSortedPartition(b *testing.B) {
+	for _, bench := range SortedSetBenchs {
+		b.Run(fmt.Sprintf("size=%d", bench.size), func(b *testing.B) {
+			for range b.N {
+				SortedPartition(bench.s1, bench.s2)
+			}
+		})
+	}
+}
+
+func BenchmarkGallopIntersection(b *testing.B) {
+	smalls := []int{50, 1000}
+	ratios := []int{1, 4, 16, 64, 1000, 100_000}
+
+	for _, small := range smalls {
+		for _, ratio := range ratios {
+			large := small * ratio
+			if large > 5_000_000 {
+				continue
+			}
+
+			s1 := RandomSortedInts(small, 10*large)
+			s2 := RandomSortedInts(large, 10*large)
+			unique := SortedUnique(s1)
+			buf := make([]int, len(unique))
+
+			b.Run(fmt.Sprintf("merge/%d/%d", small, large), func(b *testing.B) {
+				for range b.N {
+					copy(buf, unique)
+					mergeIntersect2(buf, s2)
+				}
+			})
+
+			b.Run(fmt.Sprintf("gallop/%d/%d", small, large), func(b *testing.B) {
+				for range b.N {
+					copy(buf, unique)
+					gallopIntersect2(buf, s2)
+				}
+			})
+
+			b.Run(fmt.Sprintf("map/%d/%d", small, large), func(b *testing.B) {
+				for range b.N {
+					Intersection(s1, s2)
+				}
+			})
+		}
+	}
+}
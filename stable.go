@@ -0,0 +1,264 @@
+package slicex
+
+import (
+	"cmp"
+	"slices"
+)
+
+// This file contains the stable variants of the selections, whose results only depend on the
+// order of the input. In [MaxK] and [Pairs.MaxK], which of the elements with equal values are kept
+// depends on the order in which they are scanned and on the unstable sorting of the result.
+
+// MinKStable returns a new slice with the k smallest elements in s, sorted in ascending order.
+// Among equal elements, the ones kept are the first to appear in s, and they are sorted in the order they appear.
+// As in [MinKCopy], the original slice is never written to.
+// NaNs are smaller than any other value, as in [cmp.Compare].
+func MinKStable[E cmp.Ordered](s []E, k int) []E {
+	return stableSelect(s, k, 1, minK[E], splitThreshold[E], identity[E], false)
+}
+
+// MaxKStable returns a new slice with the k biggest elements in s, sorted in descending order.
+// Among equal elements, the ones kept are the first to appear in s, and they are sorted in the order they appear.
+// As in [MaxKCopy], the original slice is never written to.
+// NaNs are smaller than any other value, as in [cmp.Compare].
+func MaxKStable[E cmp.Ordered](s []E, k int) []E {
+	return stableSelect(s, k, 1, maxK[E], splitThreshold[E], identity[E], true)
+}
+
+// MinKStable returns new pairs with the k smallest pairs by value, sorted in ascending order.
+// Among pairs with equal values, the ones kept are the first to appear in p, and they are sorted
+// in the order they appear. As in [Pairs.MinKCopy], the original pairs are never written to.
+// NaN values are smaller than any other value, as in [cmp.Compare].
+func (p Pairs[K, V]) MinKStable(k int) Pairs[K, V] {
+	return p.stableSelect(k, 1, false)
+}
+
+// MaxKStable returns new pairs with the k biggest pairs by value, sorted in descending order.
+// Among pairs with equal values, the ones kept are the first to appear in p, and they are sorted
+// in the order they appear. As in [Pairs.MaxKCopy], the original pairs are never written to.
+// NaN values are smaller than any other value, as in [cmp.Compare].
+func (p Pairs[K, V]) MaxKStable(k int) Pairs[K, V] {
+	return p.stableSelect(k, 1, true)
+}
+
+// MinKTiesByKey returns new pairs with the k smallest pairs by value, sorted in ascending order.
+// Among pairs with equal values, the ones kept are those with the smallest keys, and they are sorted by key.
+// Unlike [Pairs.MinKStable], the result doesn't depend on the order of p.
+func MinKTiesByKey[K, V cmp.Ordered](p Pairs[K, V], k int) Pairs[K, V] {
+	return minKCopyFunc(p, k, func(p1, p2 Pair[K, V]) int {
+		return cmp.Or(cmp.Compare(p1.Val, p2.Val), cmp.Compare(p1.Key, p2.Key))
+	})
+}
+
+// MaxKTiesByKey returns new pairs with the k biggest pairs by value, sorted in descending order.
+// Among pairs with equal values, the ones kept are those with the smallest keys, and they are sorted by key.
+// Unlike [Pairs.MaxKStable], the result doesn't depend on the order of p.
+func MaxKTiesByKey[K, V cmp.Ordered](p Pairs[K, V], k int) Pairs[K, V] {
+	return minKCopyFunc(p, k, func(p1, p2 Pair[K, V]) int {
+		return cmp.Or(cmp.Compare(p2.Val, p1.Val), cmp.Compare(p1.Key, p2.Key))
+	})
+}
+
+// minKCopyFunc returns a new slice with the k smallest elements in s according to cmp, sorted in ascending order.
+func minKCopyFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) S {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	k = min(k, len(s))
+	mins := make(S, k)
+	copy(mins, s[:k])
+
+	selectFunc(mins, s[k:], cmp)
+	slices.SortFunc(mins, cmp)
+	return mins
+}
+
+// stableSelect returns the k best elements of s, where the best are the smallest values, or the biggest
+// if desc is true. NaNs are smaller than any other value, as in [cmp.Compare].
+// Among elements with equal values, the first to appear are the best.
+//
+// It first finds the threshold, which is the k-th best number, by selecting the k best numbers of each
+// shard with the typed selection, which skips the NaNs because all their comparisons are false.
+// Then it splits the elements better than the threshold from the first ones equal to it, which fill
+// the k elements. selection and split are typed for s, so that the passes over s don't call val.
+func stableSelect[S ~[]E, E any, V cmp.Ordered](
+	s S,
+	k, workers int,
+	selection func(window, rest S),
+	split func(s S, t threshold[V], k int) (better, equal S),
+	val func(E) V,
+	desc bool,
+) S {
+	if k < 1 || len(s) == 0 {
+		return nil
+	}
+
+	k = min(k, len(s))
+	w := numWorkers(workers, len(s))
+
+	bests := make([]S, w)
+	parallel(len(s), w, func(i, lo, hi int) {
+		// the window must not contain NaNs, or the selection would never replace them
+		window := make(S, 0, k)
+		j := lo
+		for ; j < hi && len(window) < k; j++ {
+			if !isNaN(val(s[j])) {
+				window = append(window, s[j])
+			}
+		}
+
+		if j < hi {
+			selection(window, s[j:hi])
+		}
+		bests[i] = window
+	})
+
+	var vals []V
+	for _, best := range bests {
+		for _, e := range best {
+			vals = append(vals, val(e))
+		}
+	}
+
+	// With fewer than k numbers, all of them are selected. In ascending order the NaNs
+	// are better than the threshold, and the split keeps only the first k of them.
+	t := threshold[V]{desc: desc}
+	switch {
+	case len(vals) >= k && desc:
+		NthElement(vals, len(vals)-k)
+		t.value = vals[len(vals)-k]
+	case len(vals) >= k:
+		NthElement(vals, k-1)
+		t.value = vals[k-1]
+	case len(vals) > 0 && !desc:
+		t.value = slices.Max(vals)
+	default:
+		t.nan = true
+	}
+
+	better := make([]S, w)
+	equal := make([]S, w)
+	parallel(len(s), w, func(i, lo, hi int) {
+		better[i], equal[i] = split(s[lo:hi], t, k)
+	})
+
+	result := make(S, 0, k)
+	for _, b := range better {
+		result = append(result, b...)
+	}
+
+	slices.SortStableFunc(result, func(e1, e2 E) int {
+		if desc {
+			return cmp.Compare(val(e2), val(e1))
+		}
+		return cmp.Compare(val(e1), val(e2))
+	})
+	result = result[:min(len(result), k)]
+
+	for _, eq := range equal {
+		if len(result) == k {
+			break
+		}
+		result = append(result, eq[:min(len(eq), k-len(result))]...)
+	}
+	return result
+}
+
+// threshold is the k-th best number of a stable selection, or NaN if nan is true.
+// The best values are the smallest, or the biggest if desc is true.
+type threshold[V cmp.Ordered] struct {
+	value     V
+	nan, desc bool
+}
+
+// compare returns a negative number if v is better than the threshold, zero if it's equal,
+// and a positive number if it's worse. NaNs are smaller than any other value.
+func (t threshold[V]) compare(v V) int {
+	switch {
+	case isNaN(v) != t.nan:
+		// NaNs are better than numbers in ascending order
+		if isNaN(v) != t.desc {
+			return -1
+		}
+		return 1
+
+	case isNaN(v) || v == t.value:
+		return 0
+
+	case (v < t.value) != t.desc:
+		return -1
+
+	default:
+		return 1
+	}
+}
+
+// worse reports whether v is a number worse than the threshold, like most values.
+// It's the fast path of [threshold.compare], for the passes over all the values.
+func (t threshold[V]) worse(v V) bool {
+	return !t.nan && (t.desc && v < t.value || !t.desc && v > t.value)
+}
+
+// splitThreshold returns the elements of s better than the threshold, and the first k equal to it.
+// Of the NaNs better than the threshold, only the first k are returned, since no more can be selected.
+func splitThreshold[E cmp.Ordered](s []E, t threshold[E], k int) (better, equal []E) {
+	var nans int
+	for _, e := range s {
+		if t.worse(e) {
+			continue
+		}
+
+		switch c := t.compare(e); {
+		case c < 0 && isNaN(e):
+			if nans < k {
+				better = append(better, e)
+				nans++
+			}
+		case c < 0:
+			better = append(better, e)
+		case c == 0 && len(equal) < k:
+			equal = append(equal, e)
+		}
+	}
+	return better, equal
+}
+
+// splitThreshold returns the pairs of p whose values are better than the threshold,
+// and the first k whose values are equal to it, as in [splitThreshold].
+func (p Pairs[K, V]) splitThreshold(t threshold[V], k int) (better, equal Pairs[K, V]) {
+	var nans int
+	for _, e := range p {
+		if t.worse(e.Val) {
+			continue
+		}
+
+		switch c := t.compare(e.Val); {
+		case c < 0 && isNaN(e.Val):
+			if nans < k {
+				better = append(better, e)
+				nans++
+			}
+		case c < 0:
+			better = append(better, e)
+		case c == 0 && len(equal) < k:
+			equal = append(equal, e)
+		}
+	}
+	return better, equal
+}
+
+func (p Pair[K, V]) value() V { return p.Val }
+
+func identity[E any](e E) E { return e }
+
+func descending[E cmp.Ordered](a, b E) int { return cmp.Compare(b, a) }
+
+// stableSelect is [stableSelect] for pairs, which are compared by value.
+func (p Pairs[K, V]) stableSelect(k, workers int, desc bool) Pairs[K, V] {
+	selection := Pairs[K, V].minK
+	if desc {
+		selection = Pairs[K, V].maxK
+	}
+	return stableSelect(p, k, workers, selection, Pairs[K, V].splitThreshold, Pair[K, V].value, desc)
+}
@@ -0,0 +1,225 @@
+package slicex
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMaxKStable(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		s := []float64{0, 1, math.Copysign(0, -1), 2}
+		tests := []struct {
+			k          int
+			maxs, mins []float64
+		}{
+			{k: 0, maxs: nil, mins: nil},
+			{k: 2, maxs: []float64{2, 1}, mins: []float64{0, math.Copysign(0, -1)}},
+			{k: 3, maxs: []float64{2, 1, 0}, mins: []float64{0, math.Copysign(0, -1), 1}},
+		}
+
+		for i, test := range tests {
+			// 0 and -0 are equal, but they can be told apart by their sign
+			maxs := MaxKStable(s, test.k)
+			if !slices.Equal(maxs, test.maxs) || (len(maxs) == 3 && math.Signbit(maxs[2])) {
+				t.Errorf("test %d: expected %v, got %v", i, test.maxs, maxs)
+			}
+
+			mins := MinKStable(s, test.k)
+			if !slices.Equal(mins, test.mins) || (len(mins) > 1 && (math.Signbit(mins[0]) || !math.Signbit(mins[1]))) {
+				t.Errorf("test %d: expected %v, got %v", i, test.mins, mins)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		// NaNs are smaller than any other value, as in cmp.Compare
+		s := []float64{nan, 3, nan, 1, 2}
+		tests := []struct {
+			k          int
+			maxs, mins []float64
+		}{
+			{k: 2, maxs: []float64{3, 2}, mins: []float64{nan, nan}},
+			{k: 3, maxs: []float64{3, 2, 1}, mins: []float64{nan, nan, 1}},
+			{k: 5, maxs: []float64{3, 2, 1, nan, nan}, mins: []float64{nan, nan, 1, 2, 3}},
+		}
+
+		for i, test := range tests {
+			if maxs := MaxKStable(s, test.k); !equalNaN(maxs, test.maxs) {
+				t.Errorf("test %d: expected %v, got %v", i, test.maxs, maxs)
+			}
+
+			if mins := MinKStable(s, test.k); !equalNaN(mins, test.mins) {
+				t.Errorf("test %d: expected %v, got %v", i, test.mins, mins)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			s := RandomInts(rand.IntN(1000), 100)
+			k := rand.IntN(200)
+			original := slices.Clone(s)
+
+			if maxs, expected := MaxKStable(s, k), MaxKCopy(s, k); !slices.Equal(maxs, expected) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected, maxs)
+			}
+
+			if mins, expected := MinKStable(s, k), MinKCopy(s, k); !slices.Equal(mins, expected) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected, mins)
+			}
+
+			if !slices.Equal(s, original) {
+				t.Fatalf("the original slice was modified")
+			}
+		}
+	})
+}
+
+func TestPairsMaxKStable(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		p := Pairs[string, int]{{"f", 1}, {"e", 3}, {"d", 2}, {"c", 3}, {"b", 1}, {"a", 2}}
+		tests := []struct {
+			k          int
+			maxs, mins Pairs[string, int]
+		}{
+			{k: 0, maxs: nil, mins: nil},
+			{k: 1, maxs: Pairs[string, int]{{"e", 3}}, mins: Pairs[string, int]{{"f", 1}}},
+			{k: 3, maxs: Pairs[string, int]{{"e", 3}, {"c", 3}, {"d", 2}}, mins: Pairs[string, int]{{"f", 1}, {"b", 1}, {"d", 2}}},
+		}
+
+		for i, test := range tests {
+			if maxs := p.MaxKStable(test.k); !slices.Equal(maxs, test.maxs) {
+				t.Errorf("test %d: expected %v, got %v", i, test.maxs, maxs)
+			}
+
+			if mins := p.MinKStable(test.k); !slices.Equal(mins, test.mins) {
+				t.Errorf("test %d: expected %v, got %v", i, test.mins, mins)
+			}
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		p := Pack([]string{"a", "b", "c", "d", "e"}, []float64{nan, 1, 2, 3, nan})
+		tests := []struct {
+			k          int
+			maxs, mins []string
+		}{
+			{k: 2, maxs: []string{"d", "c"}, mins: []string{"a", "e"}},
+			{k: 4, maxs: []string{"d", "c", "b", "a"}, mins: []string{"a", "e", "b", "c"}},
+		}
+
+		for i, test := range tests {
+			if maxs := p.MaxKStable(test.k).Keys(); !slices.Equal(maxs, test.maxs) {
+				t.Errorf("test %d: expected %v, got %v", i, test.maxs, maxs)
+			}
+
+			if mins := p.MinKStable(test.k).Keys(); !slices.Equal(mins, test.mins) {
+				t.Errorf("test %d: expected %v, got %v", i, test.mins, mins)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			// few distinct values, so that there are many ties
+			size := rand.IntN(1000)
+			p := Pack(RandomInts(size, 1<<30), RandomInts(size, 20))
+			k := rand.IntN(200)
+			original := slices.Clone(p)
+
+			expected := slices.Clone(p)
+			slices.SortStableFunc(expected, func(p1, p2 Pair[int, int]) int { return cmp.Compare(p2.Val, p1.Val) })
+			expected = expected[:min(k, size)]
+
+			if maxs := p.MaxKStable(k); !slices.Equal(maxs, expected) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected, maxs)
+			}
+
+			expected = slices.Clone(p)
+			slices.SortStableFunc(expected, func(p1, p2 Pair[int, int]) int { return cmp.Compare(p1.Val, p2.Val) })
+			expected = expected[:min(k, size)]
+
+			if mins := p.MinKStable(k); !slices.Equal(mins, expected) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected, mins)
+			}
+
+			if !slices.Equal(p, original) {
+				t.Fatalf("the original pairs were modified")
+			}
+		}
+	})
+}
+
+func TestMaxKTiesByKey(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		p := Pairs[string, int]{{"f", 1}, {"e", 3}, {"d", 2}, {"c", 3}, {"b", 1}, {"a", 2}}
+		tests := []struct {
+			k          int
+			maxs, mins Pairs[string, int]
+		}{
+			{k: 0, maxs: nil, mins: nil},
+			{k: 1, maxs: Pairs[string, int]{{"c", 3}}, mins: Pairs[string, int]{{"b", 1}}},
+			{k: 3, maxs: Pairs[string, int]{{"c", 3}, {"e", 3}, {"a", 2}}, mins: Pairs[string, int]{{"b", 1}, {"f", 1}, {"a", 2}}},
+		}
+
+		for i, test := range tests {
+			if maxs := MaxKTiesByKey(p, test.k); !slices.Equal(maxs, test.maxs) {
+				t.Errorf("test %d: expected %v, got %v", i, test.maxs, maxs)
+			}
+
+			if mins := MinKTiesByKey(p, test.k); !slices.Equal(mins, test.mins) {
+				t.Errorf("test %d: expected %v, got %v", i, test.mins, mins)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			size := rand.IntN(1000)
+			p := Pack(RandomInts(size, 1<<30), RandomInts(size, 20))
+			k := rand.IntN(200)
+
+			expected := slices.Clone(p)
+			slices.SortFunc(expected, func(p1, p2 Pair[int, int]) int {
+				return cmp.Or(cmp.Compare(p2.Val, p1.Val), cmp.Compare(p1.Key, p2.Key))
+			})
+			expected = expected[:min(k, size)]
+
+			if maxs := MaxKTiesByKey(p, k); !slices.Equal(maxs, expected) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected, maxs)
+			}
+
+			// shuffling the pairs doesn't change the result
+			Shuffle(p)
+			if maxs := MaxKTiesByKey(p, k); !slices.Equal(maxs, expected) {
+				t.Fatalf("k=%d: expected %v, got %v", k, expected, maxs)
+			}
+		}
+	})
+}
+
+func BenchmarkPairsMaxKStable(b *testing.B) {
+	for _, bench := range SortBenchs {
+		p := toPairs(bench)
+		b.Run(fmt.Sprintf("max_10/%d", len(bench)), func(b *testing.B) {
+			for range b.N {
+				p.MaxKStable(10)
+			}
+		})
+	}
+}
+
+func BenchmarkMaxKTiesByKey(b *testing.B) {
+	for _, bench := range SortBenchs {
+		p := toPairs(bench)
+		b.Run(fmt.Sprintf("max_10/%d", len(bench)), func(b *testing.B) {
+			for range b.N {
+				MaxKTiesByKey(p, 10)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package slicex
+
+import (
+	"cmp"
+	"iter"
+	"math"
+)
+
+// TopK accumulates the k biggest elements pushed into it, using bounded memory.
+// It's the streaming counterpart of [MaxK], for when elements are produced
+// one at a time (e.g. from an iterator or a channel) and don't fit in memory at once.
+//
+// Once k elements have been pushed, each new element replaces the smallest one
+// if it's bigger, as in [MaxK]. The zero value is not usable, use [NewTopK].
+type TopK[E cmp.Ordered] struct {
+	k     int
+	items []E
+
+	// heap reports whether items is kept as a min-heap, in which case the worst
+	// element is items[0]. Otherwise its position is tracked by worst.
+	heap  bool
+	worst int
+}
+
+// initialTopKSize is the initial capacity of the kept elements, which grows as they are pushed,
+// so that a big k doesn't allocate memory that might never be used.
+const initialTopKSize = 64
+
+// NewTopK returns a [TopK] that keeps the k biggest elements.
+// If k is smaller than 1, no element is ever kept.
+func NewTopK[E cmp.Ordered](k int) *TopK[E] {
+	k = max(k, 0)
+	return &TopK[E]{
+		k:     k,
+		items: make([]E, 0, min(k, initialTopKSize)),
+		heap:  useHeap(k, math.MaxInt),
+	}
+}
+
+// Len returns the number of elements currently kept, which is at most k.
+func (t *TopK[E]) Len() int { return len(t.items) }
+
+// Push adds the element to the top-k if it's among the k biggest seen so far.
+func (t *TopK[E]) Push(e E) {
+	if len(t.items) < t.k {
+		t.items = append(t.items, e)
+		if len(t.items) == t.k {
+			t.init()
+		}
+		return
+	}
+
+	if t.k == 0 {
+		return
+	}
+
+	if t.heap {
+		if e > t.items[0] {
+			// swap out the smallest element with the new one
+			t.items[0] = e
+			siftDownMin(t.items, 0)
+		}
+		return
+	}
+
+	if e > t.items[t.worst] {
+		// swap out the smallest element with the new one
+		t.items[t.worst] = e
+		t.worst, _ = Min(t.items)
+	}
+}
+
+// PushAll pushes all the elements of the sequence.
+func (t *TopK[E]) PushAll(seq iter.Seq[E]) {
+	for e := range seq {
+		t.Push(e)
+	}
+}
+
+// Threshold returns the smallest of the kept elements, which a new element must exceed
+// to enter the top-k. It returns false if fewer than k elements have been kept,
+// in which case any new element is accepted.
+func (t *TopK[E]) Threshold() (E, bool) {
+	if t.k == 0 || len(t.items) < t.k {
+		var zero E
+		return zero, false
+	}
+
+	if t.heap {
+		return t.items[0], true
+	}
+	return t.items[t.worst], true
+}
+
+// Result returns a new slice with the kept elements, sorted in descending order.
+// The top-k can continue to be used after calling Result.
+func (t *TopK[E]) Result() []E {
+	if len(t.items) == 0 {
+		return nil
+	}
+
+	result := make([]E, len(t.items))
+	copy(result, t.items)
+	SortDescending(result)
+	return result
+}
+
+// init sets up the replacement strategy once the top-k becomes full.
+func (t *TopK[E]) init() {
+	if t.heap {
+		heapifyMin(t.items)
+		return
+	}
+	t.worst, _ = Min(t.items)
+}
+
+// TopKPairs accumulates the k pairs with the biggest values pushed into it,
+// using bounded memory. It's the streaming counterpart of [Pairs.MaxK].
+//
+// The zero value is not usable, use [NewTopKPairs].
+type TopKPairs[K comparable, V cmp.Ordered] struct {
+	k     int
+	items Pairs[K, V]
+
+	// heap reports whether items is kept as a min-heap, in which case the worst
+	// pair is items[0]. Otherwise its position is tracked by worst.
+	heap  bool
+	worst int
+}
+
+// NewTopKPairs returns a [TopKPairs] that keeps the k pairs with the biggest values.
+// If k is smaller than 1, no pair is ever kept.
+func NewTopKPairs[K comparable, V cmp.Ordered](k int) *TopKPairs[K, V] {
+	k = max(k, 0)
+	return &TopKPairs[K, V]{
+		k:     k,
+		items: make(Pairs[K, V], 0, min(k, initialTopKSize)),
+		heap:  useHeap(k, math.MaxInt),
+	}
+}
+
+// Len returns the number of pairs currently kept, which is at most k.
+func (t *TopKPairs[K, V]) Len() int { return len(t.items) }
+
+// Push adds the pair to the top-k if its value is among the k biggest seen so far.
+func (t *TopKPairs[K, V]) Push(p Pair[K, V]) {
+	if len(t.items) < t.k {
+		t.items = append(t.items, p)
+		if len(t.items) == t.k {
+			t.init()
+		}
+		return
+	}
+
+	if t.k == 0 {
+		return
+	}
+
+	if t.heap {
+		if p.Val > t.items[0].Val {
+			// swap out the smallest element with the new one
+			t.items[0] = p
+			t.items.siftDownMin(0)
+		}
+		return
+	}
+
+	if p.Val > t.items[t.worst].Val {
+		// swap out the smallest element with the new one
+		t.items[t.worst] = p
+		t.worst, _ = t.items.minVal()
+	}
+}
+
+// PushAll pushes all the key-value pairs of the sequence.
+func (t *TopKPairs[K, V]) PushAll(seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		t.Push(Pair[K, V]{Key: k, Val: v})
+	}
+}
+
+// Threshold returns the smallest of the kept values, which the value of a new pair must
+// exceed to enter the top-k. It returns false if fewer than k pairs have been kept,
+// in which case any new pair is accepted.
+func (t *TopKPairs[K, V]) Threshold() (V, bool) {
+	if t.k == 0 || len(t.items) < t.k {
+		var zero V
+		return zero, false
+	}
+
+	if t.heap {
+		return t.items[0].Val, true
+	}
+	return t.items[t.worst].Val, true
+}
+
+// Result returns new pairs with the kept pairs, sorted by value in descending order.
+// The top-k can continue to be used after calling Result.
+func (t *TopKPairs[K, V]) Result() Pairs[K, V] {
+	if len(t.items) == 0 {
+		return nil
+	}
+
+	result := make(Pairs[K, V], len(t.items))
+	copy(result, t.items)
+	result.SortDescending()
+	return result
+}
+
+// init sets up the replacement strategy once the top-k becomes full.
+func (t *TopKPairs[K, V]) init() {
+	if t.heap {
+		t.items.heapifyMin()
+		return
+	}
+	t.worst, _ = t.items.minVal()
+}
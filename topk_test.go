@@ -0,0 +1,126 @@
+package slicex
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			s         []int
+			k         int
+			expected  []int
+			threshold int
+			full      bool
+		}{
+			{s: nil, k: 1, expected: nil},
+			{s: []int{1, 2}, k: 0, expected: nil},
+			{s: []int{1, 2}, k: -1, expected: nil},
+			{s: []int{0, 3, 1}, k: 10, expected: []int{3, 1, 0}},
+			{s: []int{0, 3, 1}, k: math.MaxInt, expected: []int{3, 1, 0}},
+			{s: []int{0, 3, 1, 5, 1, -1, 2, 99, 32, -11}, k: 3, expected: []int{99, 32, 5}, threshold: 5, full: true},
+		}
+
+		for i, test := range tests {
+			top := NewTopK[int](test.k)
+			top.PushAll(slices.Values(test.s))
+
+			if result := top.Result(); !reflect.DeepEqual(result, test.expected) {
+				t.Fatalf("test %d: expected top %v, got %v", i, test.expected, result)
+			}
+
+			if top.Len() != len(test.expected) {
+				t.Fatalf("test %d: expected len %d, got %d", i, len(test.expected), top.Len())
+			}
+
+			threshold, full := top.Threshold()
+			if threshold != test.threshold || full != test.full {
+				t.Fatalf("test %d: expected threshold (%v, %v), got (%v, %v)", i, test.threshold, test.full, threshold, full)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		const iter = 1000
+		const size = 1000
+
+		for range iter {
+			k := rand.IntN(size)
+			s := RandomFloats(rand.IntN(size) + 1)
+
+			top := NewTopK[float64](k)
+			for _, e := range s {
+				top.Push(e)
+			}
+
+			result := top.Result()
+			expected := MaxKNaive(s, k)
+
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("len(s) = %d; k = %d", len(s), k)
+				t.Fatalf("expected top %v, got %v", expected, result)
+			}
+		}
+	})
+}
+
+func TestTopKPairs(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			pairs     Pairs[string, int]
+			k         int
+			expected  Pairs[string, int]
+			threshold int
+			full      bool
+		}{
+			{pairs: nil, k: 1, expected: nil},
+			{pairs: Pairs[string, int]{{Key: "0", Val: 0}}, k: 0, expected: nil},
+			{pairs: Pairs[string, int]{{Key: "0", Val: 0}, {Key: "3", Val: 3}, {Key: "1", Val: 1}}, k: 10, expected: Pairs[string, int]{{Key: "3", Val: 3}, {Key: "1", Val: 1}, {Key: "0", Val: 0}}},
+			{pairs: Pairs[string, int]{{Key: "0", Val: 0}, {Key: "3", Val: 3}, {Key: "1", Val: 1}}, k: math.MaxInt, expected: Pairs[string, int]{{Key: "3", Val: 3}, {Key: "1", Val: 1}, {Key: "0", Val: 0}}},
+			{pairs: Pairs[string, int]{{Key: "-1", Val: -1}, {Key: "3", Val: 3}, {Key: "1", Val: 1}}, k: 2, expected: Pairs[string, int]{{Key: "3", Val: 3}, {Key: "1", Val: 1}}, threshold: 1, full: true},
+		}
+
+		for i, test := range tests {
+			top := NewTopKPairs[string, int](test.k)
+			for _, p := range test.pairs {
+				top.Push(p)
+			}
+
+			if result := top.Result(); !reflect.DeepEqual(result, test.expected) {
+				t.Fatalf("test %d: expected top %v, got %v", i, test.expected, result)
+			}
+
+			threshold, full := top.Threshold()
+			if threshold != test.threshold || full != test.full {
+				t.Fatalf("test %d: expected threshold (%v, %v), got (%v, %v)", i, test.threshold, test.full, threshold, full)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		const iter = 1000
+		const size = 1000
+
+		for range iter {
+			k := rand.IntN(size)
+			s := RandomFloats(rand.IntN(size) + 1)
+
+			top := NewTopKPairs[int, float64](k)
+			for _, p := range toPairs(s) {
+				top.Push(p)
+			}
+
+			result := top.Result()
+			expected := toPairs(s).MaxKNaive(k)
+
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("len(p) = %d; k = %d", len(s), k)
+				t.Fatalf("expected top %v, got %v", expected, result)
+			}
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package slicex
+
+// PartitionN returns the Venn regions of the inputs, which generalizes [Partition] to any number of slices.
+// The result maps each membership bitmask to the unique elements that are in exactly the inputs
+// of that bitmask: bit i is set if and only if the elements are found in inputs[i].
+// For example, with three inputs the mask 0b101 holds the elements found in the first and
+// third inputs, but not in the second. Regions with no elements are not present.
+//
+// The order of elements in each region is the one of their first appearance in the inputs.
+// It panics if there are more than 64 inputs.
+func PartitionN[E comparable](inputs ...[]E) map[uint64][]E {
+	if len(inputs) > 64 {
+		panic("slicex.PartitionN: more than 64 inputs")
+	}
+
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	masks := make(map[E]uint64, size)
+	order := make([]E, 0, size)
+
+	for i, s := range inputs {
+		bit := uint64(1) << i
+		for _, e := range s {
+			mask, found := masks[e]
+			if !found {
+				order = append(order, e)
+			}
+			masks[e] = mask | bit
+		}
+	}
+
+	regions := make(map[uint64][]E)
+	for _, e := range order {
+		mask := masks[e]
+		regions[mask] = append(regions[mask], e)
+	}
+	return regions
+}
+
+// Exactly returns a new slice containing the unique elements found in exactly m of the inputs.
+// The order of elements is the one of their first appearance in the inputs.
+func Exactly[E comparable](m int, inputs ...[]E) []E {
+	order, members := membership(inputs)
+	result := make([]E, 0, len(order))
+
+	for _, e := range order {
+		if members[e].count == m {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// AtLeast returns a new slice containing the unique elements found in at least m of the inputs,
+// which is a quorum of m out of len(inputs). With m == len(inputs) it's the same as [Intersection],
+// and with m <= 1 it's the same as [Union].
+// The order of elements is the one of their first appearance in the inputs.
+func AtLeast[E comparable](m int, inputs ...[]E) []E {
+	switch {
+	case m <= 1:
+		return Union(inputs...)
+
+	case m > len(inputs):
+		return []E{}
+	}
+
+	order, members := membership(inputs)
+	result := make([]E, 0, len(order))
+
+	for _, e := range order {
+		if members[e].count >= m {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// AtLeastCounts is like [AtLeast], but it also returns the number of inputs each element is found in,
+// as the Val of the [Pairs]. Duplicates within the same input are counted once.
+// The order of the pairs is the one of the first appearance of their elements in the inputs,
+// and they can be ranked with [Pairs.MaxK] to find the elements found in the most inputs.
+func AtLeastCounts[E comparable](m int, inputs ...[]E) Pairs[E, int] {
+	if m > len(inputs) {
+		return Pairs[E, int]{}
+	}
+
+	order, members := membership(inputs)
+	result := make(Pairs[E, int], 0, len(order))
+
+	for _, e := range order {
+		if c := members[e].count; c >= m {
+			result = append(result, Pair[E, int]{Key: e, Val: c})
+		}
+	}
+	return result
+}
+
+// member holds the number of inputs an element is found in, and the last of them.
+type member struct {
+	count int
+	last  int
+}
+
+// membership returns the unique elements of the inputs in order of first appearance,
+// and the number of inputs each of them is found in.
+func membership[E comparable](inputs [][]E) ([]E, map[E]member) {
+	var size int
+	for _, s := range inputs {
+		size += len(s)
+	}
+
+	members := make(map[E]member, size)
+	order := make([]E, 0, size)
+
+	for i, s := range inputs {
+		for _, e := range s {
+			m, found := members[e]
+			switch {
+			case !found:
+				order = append(order, e)
+				members[e] = member{count: 1, last: i}
+
+			case m.last != i:
+				// counting each input only once, regardless of the duplicates
+				members[e] = member{count: m.count + 1, last: i}
+			}
+		}
+	}
+	return order, members
+}
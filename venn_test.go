@@ -0,0 +1,114 @@
+package slicex
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestPartitionN(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		tests := []struct {
+			inputs   [][]int
+			expected map[uint64][]int
+		}{
+			{inputs: nil, expected: map[uint64][]int{}},
+			{inputs: [][]int{{1, 2, 1}}, expected: map[uint64][]int{0b1: {1, 2}}},
+			{
+				inputs:   [][]int{{1, 2, 3}, {3, 4}, {4, 1, 5, 3}},
+				expected: map[uint64][]int{0b101: {1}, 0b001: {2}, 0b111: {3}, 0b110: {4}, 0b100: {5}},
+			},
+		}
+
+		for i, test := range tests {
+			regions := PartitionN(test.inputs...)
+			if !reflect.DeepEqual(regions, test.expected) {
+				t.Errorf("test %d: expected %v, got %v", i, test.expected, regions)
+			}
+		}
+	})
+
+	t.Run("fuzzy", func(t *testing.T) {
+		for range 100 {
+			s1 := RandomInts(rand.IntN(100), 50)
+			s2 := RandomInts(rand.IntN(100), 50)
+
+			regions := PartitionN(s1, s2)
+			d12, inter, d21 := Partition(s1, s2)
+
+			if !slices.Equal(regions[0b01], d12) || !slices.Equal(regions[0b11], inter) || !slices.Equal(regions[0b10], d21) {
+				t.Fatalf("expected (%v,%v,%v), got (%v,%v,%v)", d12, inter, d21, regions[0b01], regions[0b11], regions[0b10])
+			}
+		}
+	})
+}
+
+func TestExactlyAtLeast(t *testing.T) {
+	inputs := [][]int{{1, 2, 3, 3}, {3, 4, 4}, {4, 1, 5, 3}}
+	tests := []struct {
+		m                int
+		exactly, atLeast []int
+	}{
+		{m: 0, exactly: []int{}, atLeast: []int{1, 2, 3, 4, 5}},
+		{m: 1, exactly: []int{2, 5}, atLeast: []int{1, 2, 3, 4, 5}},
+		{m: 2, exactly: []int{1, 4}, atLeast: []int{1, 3, 4}},
+		{m: 3, exactly: []int{3}, atLeast: []int{3}},
+		{m: 4, exactly: []int{}, atLeast: []int{}},
+	}
+
+	for i, test := range tests {
+		if exactly := Exactly(test.m, inputs...); !reflect.DeepEqual(exactly, test.exactly) {
+			t.Errorf("test %d: Exactly expected %v, got %v", i, test.exactly, exactly)
+		}
+
+		if atLeast := AtLeast(test.m, inputs...); !reflect.DeepEqual(atLeast, test.atLeast) {
+			t.Errorf("test %d: AtLeast expected %v, got %v", i, test.atLeast, atLeast)
+		}
+	}
+}
+
+func TestAtLeastCounts(t *testing.T) {
+	inputs := [][]string{{"a", "b", "c", "c"}, {"c", "d", "d"}, {"d", "a", "e", "c"}}
+	tests := []struct {
+		m        int
+		expected Pairs[string, int]
+	}{
+		{m: 0, expected: Pairs[string, int]{{"a", 2}, {"b", 1}, {"c", 3}, {"d", 2}, {"e", 1}}},
+		{m: 2, expected: Pairs[string, int]{{"a", 2}, {"c", 3}, {"d", 2}}},
+		{m: 3, expected: Pairs[string, int]{{"c", 3}}},
+		{m: 4, expected: Pairs[string, int]{}},
+	}
+
+	for i, test := range tests {
+		counts := AtLeastCounts(test.m, inputs...)
+		if !reflect.DeepEqual(counts, test.expected) {
+			t.Errorf("test %d: expected %v, got %v", i, test.expected, counts)
+		}
+
+		if atLeast := AtLeast(test.m, inputs...); !reflect.DeepEqual(atLeast, counts.Keys()) {
+			t.Errorf("test %d: AtLeast expected %v, got %v", i, counts.Keys(), atLeast)
+		}
+	}
+
+	top := AtLeastCounts(2, inputs...).MaxK(1)
+	if expected := (Pairs[string, int]{{"c", 3}}); !reflect.DeepEqual(top, expected) {
+		t.Errorf("MaxK: expected %v, got %v", expected, top)
+	}
+}
+
+func TestAtLeastFuzzy(t *testing.T) {
+	for range 100 {
+		s1 := RandomInts(rand.IntN(100), 50)
+		s2 := RandomInts(rand.IntN(100), 50)
+		s3 := RandomInts(rand.IntN(100), 50)
+
+		if all, expected := AtLeast(3, s1, s2, s3), Intersection(s1, s2, s3); !reflect.DeepEqual(FromSlice(all), FromSlice(expected)) {
+			t.Fatalf("m = 3: expected %v, got %v", expected, all)
+		}
+
+		if union, expected := AtLeast(1, s1, s2, s3), Union(s1, s2, s3); !reflect.DeepEqual(union, expected) {
+			t.Fatalf("m = 1: expected %v, got %v", expected, union)
+		}
+	}
+}